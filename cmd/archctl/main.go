@@ -0,0 +1,57 @@
+// Command archctl administra usuarios y la configuración del sistema desde la línea de comandos.
+// Todas las operaciones pasan por los casos de uso de la capa de aplicación.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go-hexagonal-template/internal/archctl"
+	"go-hexagonal-template/internal/infrastructure/config"
+)
+
+func main() {
+	// Las opciones de configuración son las del servidor y van antes del comando
+	flags := flag.NewFlagSet("archctl", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s\n\nopciones de configuración:\n", archctl.Usage)
+		flags.PrintDefaults()
+	}
+	configFlags := config.RegisterFlags(flags)
+	flags.Parse(os.Args[1:])
+	source, err := configFlags.Source()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if configFlags.PrintConfig {
+		if err := source.Print(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if _, err := config.LoadConfig(source); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Ctrl+C cancela las consultas en curso
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := archctl.Run(ctx, source, flags.Args(), os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, archctl.ErrUsage) {
+			fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, archctl.Usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
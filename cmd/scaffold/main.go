@@ -0,0 +1,103 @@
+// Command scaffold genera el esqueleto de un módulo hexagonal nuevo: modelo, puerto,
+// casos de uso, repositorio GORM, handler HTTP, mock, tests y migración.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go-hexagonal-template/internal/scaffold"
+)
+
+const usage = `uso: scaffold module <nombre> --fields "campo:tipo,campo:tipo" [--root dir] [--dry-run]
+
+tipos: string, int, int64, uint, float, float64, bool, time
+
+Ejemplo:
+  go run ./cmd/scaffold module product --fields "title:string,price:int"`
+
+// errUsage indica que los argumentos no corresponden a ningún comando
+var errUsage = errors.New("argumentos inválidos")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, time.Now()); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, now time.Time) error {
+	if len(args) == 0 || args[0] != "module" {
+		return errUsage
+	}
+	args = args[1:]
+
+	flags := flag.NewFlagSet("module", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	fields := flags.String("fields", "", "campos del modelo")
+	root := flags.String("root", ".", "raíz del repositorio")
+	dryRun := flags.Bool("dry-run", false, "muestra los ficheros sin escribirlos")
+
+	// El nombre puede ir antes o después de las opciones
+	var name string
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		name, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if name == "" && flags.NArg() > 0 {
+		name = flags.Arg(0)
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+	}
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: falta el nombre del módulo", errUsage)
+	case flags.NArg() > 0:
+		return fmt.Errorf("%w: argumentos inesperados %v", errUsage, flags.Args())
+	case *fields == "":
+		return fmt.Errorf("%w: falta --fields", errUsage)
+	}
+
+	spec, err := scaffold.NewSpec(name, *fields)
+	if err != nil {
+		return err
+	}
+	goModule, err := scaffold.ModulePath(*root)
+	if err != nil {
+		return err
+	}
+	files, err := scaffold.Render(spec, goModule, now)
+	if err != nil {
+		return err
+	}
+	if !*dryRun {
+		if err := scaffold.Write(*root, files); err != nil {
+			return err
+		}
+	}
+
+	action := "Creado"
+	if *dryRun {
+		action = "Se crearía"
+	}
+	for _, file := range files {
+		fmt.Fprintf(stdout, "%s %s\n", action, file.Path)
+	}
+	instructions, err := scaffold.Instructions(spec, goModule)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\n%s", instructions)
+	return nil
+}
@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
 	"go-hexagonal-template/internal/handlers"
 	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/module"
+	"go-hexagonal-template/internal/infrastructure/server"
 	"go-hexagonal-template/internal/middleware"
-	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
-	"log"
+	"go-hexagonal-template/internal/modules"
+	healthapplication "go-hexagonal-template/internal/modules/health/application"
+	"go-hexagonal-template/internal/modules/health/infrastructure/checks"
 
 	_ "go-hexagonal-template/docs" // Esto es importante para la documentación Swagger
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -37,13 +48,42 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
-	// Cargar variables de entorno
-	if err := godotenv.Load("../../.env"); err != nil {
-		log.Printf("Error loading .env file: %v", err)
+	// Leer la configuración de --config o CONFIG_FILE, del .env del directorio actual o
+	// --env-file, de las variables de entorno y de los flags, por ese orden de prioridad
+	flags := flag.NewFlagSet("server", flag.ExitOnError)
+	configFlags := config.RegisterFlags(flags)
+	flags.Parse(os.Args[1:])
+	source, err := configFlags.Source()
+	if err != nil {
+		log.Fatalf("Error loading configuration: %v", err)
+	}
+
+	if configFlags.PrintConfig {
+		if err := source.Print(os.Stdout); err != nil {
+			log.Fatalf("Error mostrando la configuración: %v", err)
+		}
+		if _, err := config.LoadConfig(source); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Módulos de la aplicación
+	registry, err := module.NewRegistry(modules.All()...)
+	if err != nil {
+		log.Fatalf("Error registrando módulos: %v", err)
+	}
+
+	// Subcomando de migraciones: server migrate up|down|status|create
+	if flags.Arg(0) == "migrate" {
+		if err := runMigrate(flags.Args()[1:], registry, source); err != nil {
+			log.Fatalf("Error en migraciones: %v", err)
+		}
+		return
 	}
 
 	// Cargar configuración
-	cfg, err := config.NewConfig()
+	cfg, err := config.LoadConfig(source)
 	if err != nil {
 		log.Fatalf("Error loading configuration: %v", err)
 	}
@@ -53,40 +93,146 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Al recibir SIGINT o SIGTERM se interrumpe el arranque o se apaga el servidor de forma ordenada
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Configurar las sondas: liveness solo comprueba el proceso, readiness sus dependencias.
+	// Durante el drenaje del apagado readiness y /healthy dejan de estar disponibles.
+	drain := healthapplication.NewDrainState()
+	liveness := healthapplication.NewProbeUseCase(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	readiness := healthapplication.NewProbeUseCase(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	readiness.WatchDrain(drain)
+	readiness.Register(checks.NewDiskSpaceChecker(cfg.Health.DiskPath, cfg.Health.DiskMinFreeMB<<20), 0)
+	healthHandler := handlers.NewHealthHandler(cfg.Translator, drain, liveness, readiness)
+
+	// Hasta que la aplicación arranca solo se atienden las sondas
+	handler := server.NewSwitchHandler(newRouter(cfg, healthHandler))
+	srv := server.New(handler, server.Options{
+		Addr:            fmt.Sprintf(":%s", cfg.Port),
+		ReadTimeout:     cfg.Server.ReadTimeout,
+		WriteTimeout:    cfg.Server.WriteTimeout,
+		IdleTimeout:     cfg.Server.IdleTimeout,
+		DrainPeriod:     cfg.Server.DrainPeriod,
+		ShutdownTimeout: cfg.Server.ShutdownTimeout,
+	})
+	srv.OnDrain(drain.Start)
+
+	start := func(ctx context.Context) error {
+		if err := cfg.ConnectDatabase(ctx); err != nil {
+			return err
+		}
+		// La base de datos se registra primero para cerrarse después del resto de hooks
+		srv.OnShutdown("database", func(ctx context.Context) error {
+			sqlDB, err := cfg.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		})
+
+		router, err := startApplication(ctx, cfg, registry, readiness, healthHandler)
+		if err != nil {
+			return err
+		}
+		for _, hook := range registry.ShutdownHooks() {
+			srv.OnShutdown(hook.Name, hook.Fn)
+		}
+		handler.Switch(router)
+		return nil
+	}
+
+	if cfg.Database.ConnectInBackground {
+		// El servidor arranca ya y readiness responde 503 hasta que la base de datos está
+		// disponible. Si no conecta dentro de DB_CONNECT_MAX_WAIT el proceso termina con error.
+		var started atomic.Bool
+		readiness.Register(healthapplication.NewChecker("startup", func(ctx context.Context) error {
+			if !started.Load() {
+				return errStarting
+			}
+			return nil
+		}), 0)
+
+		var cancel context.CancelCauseFunc
+		ctx, cancel = context.WithCancelCause(ctx)
+		// El apagado espera a que start termine para ejecutar los hooks que registra
+		srv.Go(func() {
+			if err := start(ctx); err != nil {
+				cancel(err)
+				return
+			}
+			started.Store(true)
+			log.Println("Aplicación lista")
+		})
+	} else if err := start(ctx); err != nil {
+		log.Fatalf("Error starting application: %v", err)
+	}
+
+	log.Printf("Servidor escuchando en :%s", cfg.Port)
+	if err := srv.Run(ctx); err != nil {
+		log.Fatalf("Error al ejecutar el servidor: %v", err)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, context.Canceled) {
+		log.Fatalf("Error starting application: %v", cause)
+	}
+	log.Println("Servidor detenido")
+}
+
+// errStarting lo informa readiness mientras la aplicación espera a la base de datos
+var errStarting = errors.New("la aplicación se está iniciando")
+
+// newRouter crea el router con los middlewares comunes, las sondas y Swagger
+func newRouter(cfg *config.Config, healthHandler *handlers.HealthHandler) *gin.Engine {
 	// Crear una instancia de Gin
 	r := gin.Default()
 
+	// Traducir los errores registrados por handlers y middlewares a problem+json
+	// y negociar el idioma de las respuestas
+	r.Use(middleware.ErrorHandler(cfg.Translator))
+	r.Use(middleware.LocaleMiddleware(cfg.Translator))
+
 	// Aplicar rate limiter a todas las rutas
 	r.Use(middleware.RateLimiterMiddleware())
 
-	// Inicializar handlers
-	healthHandler := handlers.NewHealthHandler()
-	userRepo := persistence.NewUserRepositoryImpl(cfg.DB)
-	userHandler := handlers.NewUserHandler(userRepo)
-
 	// Definir rutas públicas
 	r.GET("/healthy", healthHandler.HealthCheck)
-	r.POST("/users", userHandler.CreateUser)
-	r.POST("/login", userHandler.Login)
-
-	// Definir rutas protegidas
-	protected := r.Group("/api")
-	protected.Use(middleware.AuthMiddleware())
-	{
-		protected.GET("/users/:id", userHandler.GetUser)
-	}
+	r.GET("/livez", healthHandler.Livez)
+	r.GET("/readyz", healthHandler.Readyz)
 
 	// Configurar Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Obtener el puerto de la variable de entorno o usar 3000 por defecto
-	port := cfg.Port
-	if port == "" {
-		port = "3000"
+	return r
+}
+
+// startApplication migra el esquema, inicializa los módulos y devuelve el router completo.
+// Requiere la base de datos conectada.
+func startApplication(ctx context.Context, cfg *config.Config, registry *module.Registry, readiness *healthapplication.ProbeUseCase, healthHandler *handlers.HealthHandler) (*gin.Engine, error) {
+	// Aplicar las migraciones pendientes antes de que los módulos usen sus tablas
+	if cfg.Database.MigrateOnStart {
+		if err := cfg.Database.Migrate(ctx, cfg.DB, registry.Migrations()...); err != nil {
+			return nil, err
+		}
+	}
+	if err := registry.Init(ctx, module.Dependencies{Config: cfg}); err != nil {
+		return nil, fmt.Errorf("error inicializando módulos: %w", err)
+	}
+
+	readiness.Register(checks.NewDatabaseChecker(cfg.DB), 0)
+	for _, checker := range registry.HealthChecks() {
+		readiness.Register(checker, 0)
+	}
+
+	r := newRouter(cfg, healthHandler)
+	// Las estadísticas del pool no exigen autenticación, así que solo se publican si se activan
+	if cfg.Health.DBStatsEnabled {
+		poolStatsHandler := handlers.NewPoolStatsHandler(healthapplication.NewPoolStatsUseCase(checks.NewDatabasePoolStats(cfg.DB)))
+		r.GET("/dbstats", poolStatsHandler.DatabaseStats)
 	}
 
-	// Iniciar el servidor
-	if err := r.Run(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("Error al iniciar el servidor: %v", err)
+	// Registrar las rutas de cada módulo; las protegidas cuelgan de /api
+	if err := registry.RegisterRoutes(r); err != nil {
+		return nil, fmt.Errorf("error registrando rutas: %w", err)
 	}
+	return r, nil
 }
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/migrations"
+	"go-hexagonal-template/internal/infrastructure/module"
+)
+
+const migrateUsage = `uso: server migrate <comando>
+
+comandos:
+  up                  aplica las migraciones pendientes
+  down [n]            revierte las últimas n migraciones (1 por defecto)
+  status              muestra el estado de cada migración
+  create [-dir d] <módulo> <nombre>
+                      crea los ficheros up y down de una nueva migración del módulo`
+
+var errMigrateUsage = errors.New(migrateUsage)
+
+// runMigrate ejecuta el subcomando migrate con las migraciones de todos los módulos.
+// Solo create funciona sin base de datos.
+func runMigrate(args []string, registry *module.Registry, source *config.Source) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+	command, args := args[0], args[1:]
+
+	if command == "create" {
+		return runMigrateCreate(args)
+	}
+
+	steps := 1
+	switch {
+	case command == "down" && len(args) == 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("número de migraciones inválido %q", args[0])
+		}
+		steps = n
+	case command != "up" && command != "down" && command != "status", len(args) > 0:
+		return errMigrateUsage
+	}
+
+	databaseConfig, err := config.LoadDatabaseConfig(source)
+	if err != nil {
+		return err
+	}
+	db, err := databaseConfig.ConnectWithRetry(context.Background())
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	migrator, err := migrations.NewSQLMigrator(sqlDB, databaseConfig.Driver, registry.Migrations()...)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("Aplicada", applied)
+		if err == nil && len(applied) == 0 {
+			fmt.Println("No hay migraciones pendientes")
+		}
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations("Revertida", reverted)
+		if err == nil && len(reverted) == 0 {
+			fmt.Println("No hay migraciones aplicadas")
+		}
+		return err
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	}
+}
+
+func runMigrateCreate(args []string) error {
+	flags := flag.NewFlagSet("migrate create", flag.ContinueOnError)
+	dir := flags.String("dir", "", "directorio de las migraciones (por defecto, el del módulo)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errMigrateUsage
+	}
+	if *dir == "" {
+		*dir = migrations.ModuleDir(flags.Arg(0))
+	}
+
+	upPath, downPath, err := migrations.Create(*dir, flags.Arg(1), time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Creada %s\nCreada %s\n", upPath, downPath)
+	return nil
+}
+
+func printMigrations(action string, list []migrations.Migration) {
+	for _, migration := range list {
+		fmt.Printf("%s %d_%s\n", action, migration.Version, migration.Name)
+	}
+}
+
+func printStatus(statuses []migrations.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSIÓN\tNOMBRE\tESTADO\tAPLICADA")
+	for _, status := range statuses {
+		state := "pendiente"
+		switch {
+		case status.Missing:
+			state = "ausente del binario"
+		case status.Modified:
+			state = "modificada"
+		case status.Applied:
+			state = "aplicada"
+		}
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
@@ -0,0 +1,169 @@
+package archctl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/i18n"
+	"go-hexagonal-template/internal/infrastructure/outbox"
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// app agrupa la configuración y los adaptadores que necesitan los comandos
+type app struct {
+	cfg           *config.Config
+	principal     *sharedmodel.Principal
+	policy        sharedport.Policy
+	users         port.UserRepository
+	roles         port.RoleRepository
+	refreshTokens port.RefreshTokenRepository
+	revocations   port.TokenRevocationStore
+	transactions  sharedport.TxManager
+	outbox        sharedport.EventOutbox
+}
+
+// newApp carga la configuración de source igual que el servidor y conecta los repositorios
+func newApp(ctx context.Context, source *config.Source) (*app, error) {
+	cfg, err := config.LoadConfig(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.ConnectDatabase(ctx); err != nil {
+		return nil, err
+	}
+	return &app{
+		cfg:           cfg,
+		principal:     operatorPrincipal(),
+		policy:        application.NewUserPolicy(),
+		users:         persistence.NewUserRepositoryImpl(cfg.DB),
+		roles:         persistence.NewRoleRepositoryImpl(cfg.DB),
+		refreshTokens: persistence.NewRefreshTokenRepositoryImpl(cfg.DB),
+		revocations:   persistence.NewTokenRevocationStoreImpl(cfg.DB),
+		transactions:  transaction.NewGormTxManager(cfg.DB, transaction.DefaultMaxAttempts),
+		outbox:        outbox.NewGormStore(cfg.DB),
+	}, nil
+}
+
+func (a *app) Close() error {
+	sqlDB, err := a.cfg.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// withApp ejecuta fn con la aplicación conectada y la cierra al terminar
+func withApp(ctx context.Context, source *config.Source, fn func(a *app) error) (err error) {
+	a, err := newApp(ctx, source)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, a.Close())
+	}()
+	return fn(a)
+}
+
+// operatorPrincipal representa a quien ejecuta la CLI: tiene acceso al servidor y
+// a la base de datos, así que recibe los permisos del rol admin
+func operatorPrincipal() *sharedmodel.Principal {
+	principal := &sharedmodel.Principal{Roles: []string{model.RoleAdmin}}
+	for _, role := range model.DefaultRoles() {
+		if role.Name == model.RoleAdmin {
+			principal.Permissions = role.PermissionNames()
+		}
+	}
+	return principal
+}
+
+// resolveUser busca al usuario por ID si ref es numérico y por email en otro caso
+func (a *app) resolveUser(ctx context.Context, ref string) (*model.User, error) {
+	if id, err := strconv.ParseUint(ref, 10, 0); err == nil {
+		return application.NewGetUserUseCase(a.users, a.policy).Execute(ctx, a.principal, uint(id))
+	}
+	return application.NewFindUserByEmailUseCase(a.users, a.policy).Execute(ctx, a.principal, ref)
+}
+
+// newFlagSet crea el conjunto de opciones de un subcomando; los errores se informan como uso inválido
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// ParseFlags analiza las opciones de un subcomando y rechaza los argumentos sobrantes
+func ParseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("%w: argumentos inesperados %v", ErrUsage, flags.Args())
+	}
+	return nil
+}
+
+// RequireFlag falla si la opción obligatoria está vacía
+func RequireFlag(name, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%w: falta --%s", ErrUsage, name)
+	}
+	return nil
+}
+
+// ReadPassword devuelve la contraseña indicada o la primera línea de stdin, para no
+// dejarla en el historial de la shell
+func ReadPassword(password string, stdin io.Reader) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Validate aplica las mismas reglas de validación que la API HTTP y describe
+// cada campo inválido con los mensajes del catálogo
+func Validate(source *config.Source, input interface{}) error {
+	err := binding.Validator.ValidateStruct(input)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	translator, err := i18n.NewTranslator(source.String("DEFAULT_LOCALE"))
+	if err != nil {
+		return err
+	}
+	locale := translator.DefaultLocale()
+	messages := make([]string, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		var args []interface{}
+		if fieldError.Param() != "" {
+			args = append(args, fieldError.Param())
+		}
+		message, ok := translator.Translate(locale, i18n.KeyValidationPrefix+fieldError.Tag(), args...)
+		if !ok {
+			message = translator.Message(locale, i18n.KeyValidationDefault)
+		}
+		messages = append(messages, fmt.Sprintf("%s %s", strings.ToLower(fieldError.Field()), message))
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
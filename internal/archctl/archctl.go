@@ -0,0 +1,66 @@
+// Package archctl implementa los comandos de la CLI de administración: gestiona usuarios y
+// la configuración del sistema pasando por los casos de uso de la capa de aplicación.
+package archctl
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+)
+
+// Usage describe los comandos disponibles
+const Usage = `uso: archctl [opciones de configuración] <comando> <subcomando> [opciones]
+
+comandos:
+  user create --email e --name n [--password p] [--admin]
+                      crea un usuario; con --admin recibe además el rol admin
+  user reset-password --user id|email [--password p]
+                      reemplaza la contraseña y cierra todas las sesiones
+  user disable --user id|email
+                      elimina el usuario de forma lógica y cierra sus sesiones
+  user enable --user id
+                      recupera un usuario deshabilitado
+  user list [--email e] [--name n] [--deleted] [--limit n] [--json]
+                      lista los usuarios
+  token issue --user id|email
+                      emite un par de tokens para depuración (abre una sesión real)
+  config validate     valida la configuración y la conexión con la base de datos
+
+Si no se indica --password, se lee de la entrada estándar.
+
+La configuración se lee igual que en el servidor: --config o CONFIG_FILE, el .env del
+directorio actual o --env-file, las variables de entorno y los flags como --db-host, que
+van antes del comando. --print-config muestra la configuración efectiva y termina.`
+
+// ErrUsage indica que los argumentos no corresponden a ningún comando
+var ErrUsage = errors.New("argumentos inválidos")
+
+// Run ejecuta el comando de args. Los resultados se escriben en stdout y los avisos
+// en stderr, para que la salida pueda redirigirse sin mezclarlos.
+func Run(ctx context.Context, source *config.Source, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) < 2 {
+		return ErrUsage
+	}
+	command, subcommand, args := args[0], args[1], args[2:]
+
+	switch command + " " + subcommand {
+	case "user create":
+		return runUserCreate(ctx, source, args, stdin, stdout)
+	case "user reset-password":
+		return runUserResetPassword(ctx, source, args, stdin, stdout)
+	case "user disable":
+		return runUserDisable(ctx, source, args, stdout)
+	case "user enable":
+		return runUserEnable(ctx, source, args, stdout)
+	case "user list":
+		return runUserList(ctx, source, args, stdout)
+	case "token issue":
+		return runTokenIssue(ctx, source, args, stdout, stderr)
+	case "config validate":
+		return runConfigValidate(ctx, source, args, stdout)
+	default:
+		return ErrUsage
+	}
+}
@@ -0,0 +1,37 @@
+package archctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+)
+
+func runConfigValidate(ctx context.Context, source *config.Source, args []string, stdout io.Writer) error {
+	if err := ParseFlags(newFlagSet("config validate"), args); err != nil {
+		return err
+	}
+
+	// LoadConfig valida cada sección y conecta con la base de datos, igual que al arrancar el servidor
+	return withApp(ctx, source, func(a *app) error {
+		sqlDB, err := a.cfg.DB.DB()
+		if err != nil {
+			return err
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return fmt.Errorf("la base de datos no responde: %w", err)
+		}
+
+		environment := a.cfg.Environment
+		if environment == "" {
+			environment = "(vacío, se trata como producción)"
+		}
+		fmt.Fprintln(stdout, "Configuración válida")
+		fmt.Fprintf(stdout, "  entorno:       %s\n", environment)
+		fmt.Fprintf(stdout, "  firma JWT:     %s\n", a.cfg.JWT.SigningAlgorithm)
+		fmt.Fprintf(stdout, "  idioma:        %s\n", a.cfg.Translator.DefaultLocale())
+		fmt.Fprintf(stdout, "  base de datos: %s@%s:%s/%s\n", a.cfg.Database.User, a.cfg.Database.Host, a.cfg.Database.Port, a.cfg.Database.DBName)
+		return nil
+	})
+}
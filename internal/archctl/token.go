@@ -0,0 +1,45 @@
+package archctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/modules/user/application"
+)
+
+func runTokenIssue(ctx context.Context, source *config.Source, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("token issue")
+	ref := flags.String("user", "", "ID o email del usuario")
+	if err := ParseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := RequireFlag("user", *ref); err != nil {
+		return err
+	}
+
+	return withApp(ctx, source, func(a *app) error {
+		user, err := a.resolveUser(ctx, *ref)
+		if err != nil {
+			return err
+		}
+
+		// Cada emisión abre una sesión nueva, igual que un login
+		familyID, err := auth.GenerateTokenID()
+		if err != nil {
+			return err
+		}
+		tokens, err := application.NewTokenIssuer(a.cfg.Tokens, a.refreshTokens, a.roles).Issue(ctx, user, familyID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stderr, "Sesión %s abierta para %s; ciérrala con /api/logout al terminar\n", familyID, user.Email)
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tokens)
+	})
+}
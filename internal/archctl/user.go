@@ -0,0 +1,189 @@
+package archctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+)
+
+func runUserCreate(ctx context.Context, source *config.Source, args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := newFlagSet("user create")
+	email := flags.String("email", "", "email del usuario")
+	name := flags.String("name", "", "nombre del usuario")
+	password := flags.String("password", "", "contraseña; si se omite se lee de stdin")
+	admin := flags.Bool("admin", false, "asignar el rol admin")
+	if err := ParseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := RequireFlag("email", *email); err != nil {
+		return err
+	}
+
+	secret, err := ReadPassword(*password, stdin)
+	if err != nil {
+		return err
+	}
+	input := application.CreateUserInput{Email: *email, Name: *name, Password: secret}
+	if err := Validate(source, input); err != nil {
+		return err
+	}
+
+	return withApp(ctx, source, func(a *app) error {
+		// Los roles deben existir aunque el servidor no haya arrancado nunca
+		if _, err := application.NewSeedRolesUseCase(a.users, a.roles, a.transactions).Execute(ctx, application.SeedRolesInput{}); err != nil {
+			return err
+		}
+
+		// Con --admin el usuario no debe quedar creado sin el rol si la asignación falla
+		var user *model.User
+		err := a.transactions.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			user, err = application.NewCreateUserUseCase(a.users, a.roles, a.transactions, a.outbox).Execute(ctx, input)
+			if err != nil {
+				return err
+			}
+			if *admin {
+				return application.NewAssignRoleUseCase(a.users, a.roles).Execute(ctx, user.ID, model.RoleAdmin)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "Usuario %d creado (%s)\n", user.ID, user.Email)
+		return nil
+	})
+}
+
+func runUserResetPassword(ctx context.Context, source *config.Source, args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := newFlagSet("user reset-password")
+	ref := flags.String("user", "", "ID o email del usuario")
+	password := flags.String("password", "", "nueva contraseña; si se omite se lee de stdin")
+	if err := ParseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := RequireFlag("user", *ref); err != nil {
+		return err
+	}
+
+	secret, err := ReadPassword(*password, stdin)
+	if err != nil {
+		return err
+	}
+	if err := Validate(source, application.PatchUserInput{Password: &secret}); err != nil {
+		return err
+	}
+
+	return withApp(ctx, source, func(a *app) error {
+		user, err := a.resolveUser(ctx, *ref)
+		if err != nil {
+			return err
+		}
+		useCase := application.NewResetPasswordUseCase(a.users, a.policy, a.revocations, a.refreshTokens, a.transactions, a.outbox)
+		if err := useCase.Execute(ctx, a.principal, user.ID, secret); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "Contraseña de %s restablecida; sus sesiones se han cerrado\n", user.Email)
+		return nil
+	})
+}
+
+func runUserDisable(ctx context.Context, source *config.Source, args []string, stdout io.Writer) error {
+	flags := newFlagSet("user disable")
+	ref := flags.String("user", "", "ID o email del usuario")
+	if err := ParseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := RequireFlag("user", *ref); err != nil {
+		return err
+	}
+
+	return withApp(ctx, source, func(a *app) error {
+		user, err := a.resolveUser(ctx, *ref)
+		if err != nil {
+			return err
+		}
+		useCase := application.NewDeleteUserUseCase(a.users, a.policy, a.revocations, a.refreshTokens, a.transactions)
+		if err := useCase.Execute(ctx, a.principal, user.ID); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "Usuario %d deshabilitado (%s)\n", user.ID, user.Email)
+		return nil
+	})
+}
+
+func runUserEnable(ctx context.Context, source *config.Source, args []string, stdout io.Writer) error {
+	flags := newFlagSet("user enable")
+	ref := flags.String("user", "", "ID del usuario")
+	if err := ParseFlags(flags, args); err != nil {
+		return err
+	}
+	// Un usuario deshabilitado no se puede buscar por email: solo se acepta el ID
+	id, err := strconv.ParseUint(*ref, 10, 0)
+	if err != nil {
+		return fmt.Errorf("%w: --user debe ser un ID", ErrUsage)
+	}
+
+	return withApp(ctx, source, func(a *app) error {
+		user, err := application.NewRestoreUserUseCase(a.users).Execute(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "Usuario %d habilitado (%s)\n", user.ID, user.Email)
+		return nil
+	})
+}
+
+func runUserList(ctx context.Context, source *config.Source, args []string, stdout io.Writer) error {
+	flags := newFlagSet("user list")
+	email := flags.String("email", "", "filtrar por email (subcadena)")
+	name := flags.String("name", "", "filtrar por nombre (subcadena)")
+	deleted := flags.Bool("deleted", false, "incluir usuarios deshabilitados")
+	limit := flags.Int("limit", sharedmodel.MaxPageLimit, "número máximo de usuarios")
+	asJSON := flags.Bool("json", false, "salida en JSON")
+	if err := ParseFlags(flags, args); err != nil {
+		return err
+	}
+
+	return withApp(ctx, source, func(a *app) error {
+		page, err := application.NewListUsersUseCase(a.users).Execute(ctx, model.UserQuery{
+			Filter: model.UserFilter{Email: *email, Name: *name, IncludeDeleted: *deleted},
+			Page:   sharedmodel.PageRequest{Limit: *limit},
+		})
+		if err != nil {
+			return err
+		}
+
+		if *asJSON {
+			encoder := json.NewEncoder(stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(page)
+		}
+
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tEMAIL\tNOMBRE\tCREADO")
+		for _, user := range page.Items {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", user.ID, user.Email, user.Name, user.CreatedAt.Format(time.RFC3339))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if page.NextCursor != "" {
+			fmt.Fprintf(stdout, "Hay más usuarios; aumenta --limit o usa la API paginada\n")
+		}
+		return nil
+	})
+}
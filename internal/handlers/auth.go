@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	keyManager *auth.KeyManager
+}
+
+func NewAuthHandler(keyManager *auth.KeyManager) *AuthHandler {
+	return &AuthHandler{
+		keyManager: keyManager,
+	}
+}
+
+// JWKS godoc
+// @Summary Claves públicas de firma
+// @Description Publica las claves públicas con las que se verifican los tokens JWT, incluida la clave anterior durante una rotación
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keyManager.JWKS())
+}
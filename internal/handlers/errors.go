@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// errInvalidID se registra cuando el ID de la ruta no es un número válido
+	errInvalidID = apperror.Validation("INVALID_ID", "ID inválido")
+	// errInvalidClaims se registra cuando el contexto no contiene los claims de AuthMiddleware
+	errInvalidClaims = apperror.Unauthorized("INVALID_TOKEN", "Token inválido")
+)
+
+func init() {
+	// Los errores de validación usan el nombre JSON del campo en lugar del nombre Go
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// abortWithError registra el error para que ErrorHandler lo traduzca y detiene la cadena.
+// Los errores que no pertenecen al catálogo se registran como internos con el mensaje indicado.
+func abortWithError(c *gin.Context, err error, fallback string) {
+	if _, ok := apperror.As(err); !ok {
+		err = apperror.Wrap(err, apperror.KindInternal, apperror.CodeInternal, fallback)
+	}
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// bindingError convierte un error de ShouldBindJSON en un error de validación con
+// la regla incumplida por cada campo; ErrorHandler traduce los mensajes al responder.
+// Si el cuerpo no es un JSON válido no hay detalle por campo
+func bindingError(err error, message string) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return apperror.Validation("MALFORMED_BODY", message)
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fields = append(fields, apperror.FieldError{
+			Field: fieldErr.Field(),
+			Code:  fieldErr.Tag(),
+			Param: fieldErr.Param(),
+		})
+	}
+	return apperror.Validation(apperror.CodeValidationFailed, message, fields...)
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -3,22 +3,95 @@ package handlers
 import (
 	"net/http"
 
+	"go-hexagonal-template/internal/infrastructure/i18n"
 	"go-hexagonal-template/internal/modules/health/application"
+	"go-hexagonal-template/internal/modules/health/domain/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 type HealthHandler struct {
 	healthCheckUseCase *application.HealthCheckUseCase
+	livenessUseCase    *application.ProbeUseCase
+	readinessUseCase   *application.ProbeUseCase
+	translator         *i18n.Translator
 }
 
-func NewHealthHandler() *HealthHandler {
+func NewHealthHandler(translator *i18n.Translator, drain *application.DrainState, liveness, readiness *application.ProbeUseCase) *HealthHandler {
 	return &HealthHandler{
-		healthCheckUseCase: application.NewHealthCheckUseCase(),
+		healthCheckUseCase: application.NewHealthCheckUseCase(drain),
+		livenessUseCase:    liveness,
+		readinessUseCase:   readiness,
+		translator:         translator,
 	}
 }
 
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	health := h.healthCheckUseCase.Execute()
-	c.JSON(http.StatusOK, health)
+	key := i18n.KeyHealthy
+	if !health.IsUp() {
+		key = i18n.KeyDraining
+	}
+	health.Message = h.translator.Message(c.GetString(i18n.LocaleKey), key)
+	respondProbe(c, health)
+}
+
+// Livez godoc
+// @Summary Sonda de liveness
+// @Description Indica si el proceso está vivo. Solo ejecuta los chequeos que no dependen de servicios externos
+// @Tags health
+// @Produce json
+// @Success 200 {object} model.Health
+// @Failure 503 {object} model.Health
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	respondProbe(c, h.livenessUseCase.Execute(c.Request.Context()))
+}
+
+// Readyz godoc
+// @Summary Sonda de readiness
+// @Description Indica si la instancia puede recibir tráfico. Ejecuta los chequeos de dependencias (base de datos, disco y los de cada módulo) con su latencia; el resultado se cachea unos segundos
+// @Tags health
+// @Produce json
+// @Success 200 {object} model.Health
+// @Failure 503 {object} model.Health
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	respondProbe(c, h.readinessUseCase.Execute(c.Request.Context()))
+}
+
+// respondProbe responde 200 si la sonda está operativa y 503 en caso contrario
+func respondProbe(c *gin.Context, health *model.Health) {
+	status := http.StatusOK
+	if !health.IsUp() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, health)
+}
+
+type PoolStatsHandler struct {
+	poolStatsUseCase *application.PoolStatsUseCase
+}
+
+func NewPoolStatsHandler(poolStats *application.PoolStatsUseCase) *PoolStatsHandler {
+	return &PoolStatsHandler{
+		poolStatsUseCase: poolStats,
+	}
+}
+
+// DatabaseStats godoc
+// @Summary Estadísticas del pool de conexiones
+// @Description Devuelve las conexiones abiertas, en uso y ociosas de la base de datos principal y las esperas acumuladas por una conexión libre
+// @Tags health
+// @Produce json
+// @Success 200 {object} model.PoolStats
+// @Failure 500 {object} middleware.Problem
+// @Router /dbstats [get]
+func (h *PoolStatsHandler) DatabaseStats(c *gin.Context) {
+	stats, err := h.poolStatsUseCase.Execute()
+	if err != nil {
+		abortWithError(c, err, "Error al leer las estadísticas de la base de datos")
+		return
+	}
+	c.JSON(http.StatusOK, stats)
 }
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePageRequest lee limit, offset y cursor de la query string
+func parsePageRequest(c *gin.Context) (sharedmodel.PageRequest, error) {
+	var page sharedmodel.PageRequest
+	var err error
+	if value := c.Query("limit"); value != "" {
+		if page.Limit, err = strconv.Atoi(value); err != nil {
+			return page, fmt.Errorf("%w: limit debe ser un número", sharedmodel.ErrInvalidQuery)
+		}
+	}
+	if value := c.Query("offset"); value != "" {
+		if page.Offset, err = strconv.Atoi(value); err != nil {
+			return page, fmt.Errorf("%w: offset debe ser un número", sharedmodel.ErrInvalidQuery)
+		}
+	}
+	page.Cursor = c.Query("cursor")
+	return page, nil
+}
+
+// setPaginationLinks agrega la cabecera Link (RFC 8288) con las páginas siguiente y anterior.
+// Se conservan los filtros de la petición y se usa el mismo modo de paginación que el cliente.
+func setPaginationLinks[T any](c *gin.Context, page *sharedmodel.Page[T], request sharedmodel.PageRequest) {
+	var links []string
+
+	if page.NextCursor != "" {
+		if request.Cursor != "" {
+			links = append(links, pageLink(c, "next", map[string]string{"cursor": page.NextCursor}))
+		} else {
+			links = append(links, pageLink(c, "next", map[string]string{"offset": strconv.Itoa(request.Offset + len(page.Items))}))
+		}
+	}
+
+	if request.Cursor == "" && request.Offset > 0 {
+		previous := request.Offset - page.Limit
+		if previous < 0 {
+			previous = 0
+		}
+		links = append(links, pageLink(c, "prev", map[string]string{"offset": strconv.Itoa(previous)}))
+	}
+
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+func pageLink(c *gin.Context, rel string, params map[string]string) string {
+	query := c.Request.URL.Query()
+	query.Del("cursor")
+	query.Del("offset")
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	link := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf("<%s>; rel=\"%s\"", link.String(), rel)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"strconv"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalFromContext obtiene el principal que AuthMiddleware guardó en el contexto
+func principalFromContext(c *gin.Context) *sharedmodel.Principal {
+	value, exists := c.Get("principal")
+	if !exists {
+		return nil
+	}
+	principal, _ := value.(*sharedmodel.Principal)
+	return principal
+}
+
+// idParam obtiene el ID del recurso de la ruta y registra un error de validación si no es válido
+func idParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		abortWithError(c, errInvalidID, "ID inválido")
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -3,57 +3,273 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
+	"go-hexagonal-template/internal/infrastructure/auth"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
 	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/port"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	getUserUseCase    *application.GetUserUseCase
-	createUserUseCase *application.CreateUserUseCase
-	loginUserUseCase  *application.LoginUserUseCase
+	getUserUseCase      *application.GetUserUseCase
+	createUserUseCase   *application.CreateUserUseCase
+	loginUserUseCase    *application.LoginUserUseCase
+	refreshTokenUseCase *application.RefreshTokenUseCase
+	logoutUseCase       *application.LogoutUseCase
+	logoutAllUseCase    *application.LogoutAllUseCase
+	listUsersUseCase    *application.ListUsersUseCase
+	updateUserUseCase   *application.UpdateUserUseCase
+	patchUserUseCase    *application.PatchUserUseCase
+	deleteUserUseCase   *application.DeleteUserUseCase
+	restoreUserUseCase  *application.RestoreUserUseCase
+	hardDeleteUseCase   *application.HardDeleteUserUseCase
 }
 
-func NewUserHandler(userRepository port.UserRepository) *UserHandler {
+func NewUserHandler(userRepository port.UserRepository, roleRepository port.RoleRepository, refreshTokenRepository port.RefreshTokenRepository, revocationStore port.TokenRevocationStore, txManager sharedport.TxManager, outbox sharedport.EventOutbox, tokenManager *auth.TokenManager) *UserHandler {
+	tokenIssuer := application.NewTokenIssuer(tokenManager, refreshTokenRepository, roleRepository)
+	policy := application.NewUserPolicy()
 	return &UserHandler{
-		getUserUseCase:    application.NewGetUserUseCase(userRepository),
-		createUserUseCase: application.NewCreateUserUseCase(userRepository),
-		loginUserUseCase:  application.NewLoginUserUseCase(userRepository),
+		getUserUseCase:      application.NewGetUserUseCase(userRepository, policy),
+		createUserUseCase:   application.NewCreateUserUseCase(userRepository, roleRepository, txManager, outbox),
+		loginUserUseCase:    application.NewLoginUserUseCase(userRepository, tokenIssuer, txManager, outbox),
+		refreshTokenUseCase: application.NewRefreshTokenUseCase(userRepository, refreshTokenRepository, tokenIssuer, txManager),
+		logoutUseCase:       application.NewLogoutUseCase(revocationStore, refreshTokenRepository, txManager),
+		logoutAllUseCase:    application.NewLogoutAllUseCase(revocationStore, refreshTokenRepository, txManager),
+		listUsersUseCase:    application.NewListUsersUseCase(userRepository),
+		updateUserUseCase:   application.NewUpdateUserUseCase(userRepository, policy, txManager, outbox),
+		patchUserUseCase:    application.NewPatchUserUseCase(userRepository, policy, txManager, outbox),
+		deleteUserUseCase:   application.NewDeleteUserUseCase(userRepository, policy, revocationStore, refreshTokenRepository, txManager),
+		restoreUserUseCase:  application.NewRestoreUserUseCase(userRepository),
+		hardDeleteUseCase:   application.NewHardDeleteUserUseCase(userRepository, revocationStore, refreshTokenRepository, txManager),
 	}
 }
 
 // GetUser godoc
 // @Summary Obtener usuario por ID
-// @Description Obtiene los detalles de un usuario por su ID
+// @Description Obtiene los detalles de un usuario por su ID. Cada usuario puede consultarse a sí mismo; consultar a otros requiere el permiso users:read
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path string true "ID del usuario"
 // @Security Bearer
 // @Success 200 {object} model.User
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
 // @Router /api/users/{id} [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
-	idParam := c.Param("id")
-	idUint, err := strconv.ParseUint(idParam, 10, 64)
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	user, err := h.getUserUseCase.Execute(c.Request.Context(), principalFromContext(c), id)
+	if err != nil {
+		abortWithError(c, err, "Error al obtener el usuario")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ListUsers godoc
+// @Summary Listar usuarios
+// @Description Lista los usuarios con filtros, orden y paginación por desplazamiento o por cursor. La cabecera Link incluye las páginas siguiente y anterior. Requiere el permiso users:read
+// @Tags users
+// @Produce json
+// @Param email query string false "Subcadena del email (sin distinguir mayúsculas)"
+// @Param name query string false "Subcadena del nombre (sin distinguir mayúsculas)"
+// @Param created_after query string false "Creados después de esta fecha (RFC 3339)"
+// @Param created_before query string false "Creados antes de esta fecha (RFC 3339)"
+// @Param include_deleted query bool false "Incluir usuarios eliminados"
+// @Param sort query string false "Campo de orden: id, name, email, created_at o updated_at; prefijo - para descendente"
+// @Param limit query int false "Elementos por página (por defecto 20, máximo 100)"
+// @Param offset query int false "Desplazamiento; no se puede combinar con cursor"
+// @Param cursor query string false "Cursor opaco devuelto en next_cursor"
+// @Security Bearer
+// @Success 200 {object} object{items=[]model.User,limit=int,next_cursor=string,total=int}
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	query, err := parseUserQuery(c)
+	if err != nil {
+		abortWithError(c, err, "Parámetros de consulta inválidos")
+		return
+	}
+
+	page, err := h.listUsersUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		abortWithError(c, err, "Error al listar los usuarios")
+		return
+	}
+
+	setPaginationLinks(c, page, query.Page)
+	c.JSON(http.StatusOK, page)
+}
+
+// UpdateUser godoc
+// @Summary Actualizar usuario
+// @Description Reemplaza el nombre y el email del usuario; la contraseña solo cambia si se envía. Cada usuario puede actualizarse a sí mismo; actualizar a otros requiere el permiso users:write
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Param user body application.UpdateUserInput true "Datos del usuario"
+// @Security Bearer
+// @Success 200 {object} model.User
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/users/{id} [put]
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var input application.UpdateUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		abortWithError(c, bindingError(err, "Datos de usuario inválidos"), "Datos de usuario inválidos")
+		return
+	}
+
+	user, err := h.updateUserUseCase.Execute(c.Request.Context(), principalFromContext(c), id, input)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		abortWithError(c, err, "Error al actualizar el usuario")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// PatchUser godoc
+// @Summary Actualizar parcialmente un usuario
+// @Description Modifica solo los campos enviados. Cada usuario puede actualizarse a sí mismo; actualizar a otros requiere el permiso users:write
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Param user body application.PatchUserInput true "Campos a modificar"
+// @Security Bearer
+// @Success 200 {object} model.User
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var input application.PatchUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		abortWithError(c, bindingError(err, "Datos de usuario inválidos"), "Datos de usuario inválidos")
+		return
+	}
+
+	user, err := h.patchUserUseCase.Execute(c.Request.Context(), principalFromContext(c), id, input)
+	if err != nil {
+		abortWithError(c, err, "Error al actualizar el usuario")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser godoc
+// @Summary Eliminar usuario
+// @Description Elimina el usuario de forma lógica y cierra todas sus sesiones. Cada usuario puede eliminarse a sí mismo; eliminar a otros requiere el permiso users:delete
+// @Tags users
+// @Param id path string true "ID del usuario"
+// @Security Bearer
+// @Success 204
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUserUseCase.Execute(c.Request.Context(), principalFromContext(c), id); err != nil {
+		abortWithError(c, err, "Error al eliminar el usuario")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreUser godoc
+// @Summary Restaurar usuario
+// @Description Recupera un usuario eliminado de forma lógica. Requiere el permiso users:delete
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Security Bearer
+// @Success 200 {object} model.User
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/admin/users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
 		return
 	}
-	user, err := h.getUserUseCase.Execute(uint(idUint))
+
+	user, err := h.restoreUserUseCase.Execute(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Usuario no encontrado",
-		})
+		abortWithError(c, err, "Error al restaurar el usuario")
 		return
 	}
 
 	c.JSON(http.StatusOK, user)
 }
 
+// HardDeleteUser godoc
+// @Summary Eliminar usuario definitivamente
+// @Description Elimina el usuario y sus roles asignados de forma permanente. Requiere el permiso users:delete
+// @Tags admin
+// @Param id path string true "ID del usuario"
+// @Security Bearer
+// @Success 204
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/admin/users/{id} [delete]
+func (h *UserHandler) HardDeleteUser(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.hardDeleteUseCase.Execute(c.Request.Context(), id); err != nil {
+		abortWithError(c, err, "Error al eliminar el usuario")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // CreateUser godoc
 // @Summary Crear un nuevo usuario
 // @Description Crea un nuevo usuario en el sistema
@@ -62,23 +278,20 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 // @Produce json
 // @Param user body model.User true "Datos del usuario"
 // @Success 201 {object} model.User
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
 // @Router /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var input application.CreateUserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Datos de usuario inválidos",
-		})
+		abortWithError(c, bindingError(err, "Datos de usuario inválidos"), "Datos de usuario inválidos")
 		return
 	}
 
-	createdUser, err := h.createUserUseCase.Execute(input)
+	createdUser, err := h.createUserUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Error al crear el usuario",
-		})
+		abortWithError(c, err, "Error al crear el usuario")
 		return
 	}
 
@@ -87,14 +300,14 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // Login godoc
 // @Summary Autenticar usuario
-// @Description Autentica un usuario y devuelve un token JWT
+// @Description Autentica un usuario y devuelve un token JWT de corta duración junto con un refresh token
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param credentials body map[string]string true "Credenciales de usuario"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
+// @Success 200 {object} application.LoginUserOutput
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
 // @Router /login [post]
 func (h *UserHandler) Login(c *gin.Context) {
 	var credentials struct {
@@ -103,22 +316,155 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&credentials); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Credenciales inválidas",
-		})
+		abortWithError(c, bindingError(err, "Credenciales inválidas"), "Credenciales inválidas")
 		return
 	}
 
-	result, err := h.loginUserUseCase.Execute(application.LoginUserInput{
+	result, err := h.loginUserUseCase.Execute(c.Request.Context(), application.LoginUserInput{
 		Email:    credentials.Email,
 		Password: credentials.Password,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Credenciales inválidas",
-		})
+		abortWithError(c, err, "Error al iniciar sesión")
 		return
 	}
 
 	c.JSON(http.StatusOK, result)
 }
+
+// RefreshToken godoc
+// @Summary Renovar el token de acceso
+// @Description Intercambia un refresh token por un nuevo par de tokens. El refresh token presentado queda invalidado y, si se reutiliza, se revoca toda la sesión
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh_token body application.RefreshTokenInput true "Refresh token"
+// @Success 200 {object} application.TokenPair
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Router /token/refresh [post]
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var input application.RefreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		abortWithError(c, bindingError(err, "Refresh token requerido"), "Refresh token requerido")
+		return
+	}
+
+	tokens, err := h.refreshTokenUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err, "Error al renovar el token")
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Cerrar sesión
+// @Description Revoca el token de acceso presentado y los refresh tokens de la misma sesión
+// @Tags auth
+// @Security Bearer
+// @Success 204
+// @Failure 401 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+	claims, userID, ok := claimsFromContext(c)
+	if !ok {
+		abortWithError(c, errInvalidClaims, "Token inválido")
+		return
+	}
+
+	err := h.logoutUseCase.Execute(c.Request.Context(), application.LogoutInput{
+		TokenID:   claims.ID,
+		UserID:    userID,
+		SessionID: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+	if err != nil {
+		abortWithError(c, err, "Error al cerrar la sesión")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Cerrar todas las sesiones
+// @Description Revoca todos los tokens de acceso y refresh tokens emitidos para el usuario autenticado
+// @Tags auth
+// @Security Bearer
+// @Success 204
+// @Failure 401 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Router /api/logout-all [post]
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	_, userID, ok := claimsFromContext(c)
+	if !ok {
+		abortWithError(c, errInvalidClaims, "Token inválido")
+		return
+	}
+
+	if err := h.logoutAllUseCase.Execute(c.Request.Context(), userID); err != nil {
+		abortWithError(c, err, "Error al cerrar las sesiones")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// claimsFromContext obtiene los claims que AuthMiddleware guardó en el contexto
+func claimsFromContext(c *gin.Context) (*auth.Claims, uint, bool) {
+	value, exists := c.Get("claims")
+	if !exists {
+		return nil, 0, false
+	}
+	claims, ok := value.(*auth.Claims)
+	if !ok || claims.ExpiresAt == nil {
+		return nil, 0, false
+	}
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		return nil, 0, false
+	}
+	return claims, uint(userID), true
+}
+
+// parseUserQuery construye la consulta de usuarios a partir de la query string
+func parseUserQuery(c *gin.Context) (model.UserQuery, error) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		return model.UserQuery{}, err
+	}
+
+	query := model.UserQuery{
+		Filter: model.UserFilter{
+			Email: c.Query("email"),
+			Name:  c.Query("name"),
+		},
+		Sort: sharedmodel.ParseSort(c.Query("sort")),
+		Page: page,
+	}
+
+	if value := c.Query("include_deleted"); value != "" {
+		if query.Filter.IncludeDeleted, err = strconv.ParseBool(value); err != nil {
+			return query, sharedmodel.ErrInvalidQuery
+		}
+	}
+	for param, target := range map[string]**time.Time{
+		"created_after":  &query.Filter.CreatedAfter,
+		"created_before": &query.Filter.CreatedBefore,
+	} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return query, sharedmodel.ErrInvalidQuery
+		}
+		*target = &t
+	}
+
+	return query, nil
+}
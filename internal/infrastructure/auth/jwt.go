@@ -2,40 +2,129 @@ package auth
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// DefaultAccessTokenTTL es la vigencia por defecto de los tokens de acceso
+	DefaultAccessTokenTTL = 15 * time.Minute
+	// DefaultRefreshTokenTTL es la vigencia por defecto de los refresh tokens
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
+	// SessionID identifica la familia de refresh tokens con la que se emitió el token
+	SessionID   string   `json:"sid,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID, email string) (string, error) {
-	// Obtener la clave secreta del entorno
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	if secretKey == "" {
-		secretKey = "default-secret-key" // Solo para desarrollo
+// HasPermission indica si el token concede el permiso indicado
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenSubject agrupa los datos del usuario que se incluyen en el token
+type TokenSubject struct {
+	UserID      string
+	Email       string
+	SessionID   string
+	Roles       []string
+	Permissions []string
+}
+
+// TokenOptions define los parámetros de emisión y validación de tokens
+type TokenOptions struct {
+	Issuer          string
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// Leeway es la tolerancia de reloj aceptada al validar exp, nbf e iat
+	Leeway time.Duration
+}
+
+// TokenManager emite y valida tokens JWT usando las claves de un KeyManager
+type TokenManager struct {
+	keys    *KeyManager
+	options TokenOptions
+}
+
+// NewTokenManager crea una nueva instancia de TokenManager
+func NewTokenManager(keys *KeyManager, options TokenOptions) *TokenManager {
+	if options.AccessTokenTTL <= 0 {
+		options.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+	if options.RefreshTokenTTL <= 0 {
+		options.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	return &TokenManager{
+		keys:    keys,
+		options: options,
+	}
+}
+
+// AccessTokenTTL retorna la vigencia de los tokens de acceso
+func (m *TokenManager) AccessTokenTTL() time.Duration {
+	return m.options.AccessTokenTTL
+}
+
+// RefreshTokenTTL retorna la vigencia de los refresh tokens
+func (m *TokenManager) RefreshTokenTTL() time.Duration {
+	return m.options.RefreshTokenTTL
+}
+
+// Keys retorna el KeyManager usado para firmar y verificar
+func (m *TokenManager) Keys() *KeyManager {
+	return m.keys
+}
+
+func (m *TokenManager) GenerateToken(subject TokenSubject) (string, error) {
+	// Identificador único del token para poder revocarlo
+	tokenID, err := GenerateTokenID()
+	if err != nil {
+		return "", err
 	}
 
 	// Crear los claims
+	now := time.Now()
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:      subject.UserID,
+		Email:       subject.Email,
+		SessionID:   subject.SessionID,
+		Roles:       subject.Roles,
+		Permissions: subject.Permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // Token válido por 24 horas
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        tokenID,
+			Subject:   subject.UserID,
+			Issuer:    m.options.Issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.options.AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+	if m.options.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.options.Audience}
+	}
 
-	// Crear el token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// Crear el token con la clave actual
+	key := m.keys.Current()
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
 
 	// Firmar el token
-	tokenString, err := token.SignedString([]byte(secretKey))
+	tokenString, err := token.SignedString(key.private)
 	if err != nil {
 		return "", err
 	}
@@ -43,17 +132,22 @@ func GenerateToken(userID, email string) (string, error) {
 	return tokenString, nil
 }
 
-func ValidateToken(tokenString string) (*Claims, error) {
-	// Obtener la clave secreta del entorno
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	if secretKey == "" {
-		secretKey = "default-secret-key" // Solo para desarrollo
+func (m *TokenManager) ValidateToken(tokenString string) (*Claims, error) {
+	// Parsear el token aceptando solo los algoritmos de las claves configuradas
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(m.keys.Algorithms()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(m.options.Leeway),
+	}
+	if m.options.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(m.options.Issuer))
+	}
+	if m.options.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(m.options.Audience))
 	}
 
-	// Parsear el token
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keys.Keyfunc, parserOptions...)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algoritmos de firma soportados
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	// ErrUnknownKey se retorna cuando el token fue firmado con una clave desconocida o retirada
+	ErrUnknownKey = errors.New("clave de firma desconocida")
+	// ErrUnsupportedAlgorithm se retorna cuando el algoritmo configurado no está soportado
+	ErrUnsupportedAlgorithm = errors.New("algoritmo de firma no soportado")
+)
+
+// SigningKey representa una clave usada para firmar o verificar tokens
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	// ExpiresAt indica hasta cuándo se aceptan tokens firmados con la clave; cero significa sin límite
+	ExpiresAt time.Time
+
+	method  jwt.SigningMethod
+	private interface{}
+	public  interface{}
+}
+
+// NewHMACKey crea una clave simétrica HS256
+func NewHMACKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{
+		ID:        id,
+		Algorithm: AlgorithmHS256,
+		method:    jwt.SigningMethodHS256,
+		private:   secret,
+		public:    secret,
+	}
+}
+
+// NewSigningKey crea una clave asimétrica a partir de una clave privada
+func NewSigningKey(id, algorithm string, private crypto.Signer) (*SigningKey, error) {
+	key, err := newPublicKey(id, algorithm, private.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.private = private
+	return key, nil
+}
+
+// NewVerificationKey crea una clave asimétrica que solo permite verificar tokens
+func NewVerificationKey(id, algorithm string, public crypto.PublicKey) (*SigningKey, error) {
+	return newPublicKey(id, algorithm, public)
+}
+
+func newPublicKey(id, algorithm string, public crypto.PublicKey) (*SigningKey, error) {
+	key := &SigningKey{ID: id, Algorithm: algorithm, public: public}
+
+	switch algorithm {
+	case AlgorithmRS256:
+		if _, ok := public.(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("%s requiere una clave RSA", algorithm)
+		}
+		key.method = jwt.SigningMethodRS256
+	case AlgorithmES256:
+		ecKey, ok := public.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%s requiere una clave ECDSA P-256", algorithm)
+		}
+		key.method = jwt.SigningMethodES256
+	case AlgorithmEdDSA:
+		if _, ok := public.(ed25519.PublicKey); !ok {
+			return nil, fmt.Errorf("%s requiere una clave Ed25519", algorithm)
+		}
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	// Si no se indicó un identificador se usa la huella de la clave pública
+	if key.ID == "" {
+		jwk, err := key.JWK()
+		if err != nil {
+			return nil, err
+		}
+		key.ID = jwk.Thumbprint()
+	}
+
+	return key, nil
+}
+
+// CanSign indica si la clave contiene material privado para firmar
+func (k *SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+// IsSymmetric indica si la clave es un secreto compartido
+func (k *SigningKey) IsSymmetric() bool {
+	return k.Algorithm == AlgorithmHS256
+}
+
+func (k *SigningKey) isActive(now time.Time) bool {
+	return k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt)
+}
+
+// LoadPrivateKey carga una clave privada PEM (PKCS#8, PKCS#1 o SEC1) desde disco
+func LoadPrivateKey(path, algorithm, id string) (*SigningKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var private interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		private, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("tipo de bloque PEM no soportado en %s: %s", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo la clave privada %s: %v", path, err)
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("la clave de %s no permite firmar", path)
+	}
+	return NewSigningKey(id, algorithm, signer)
+}
+
+// LoadPublicKey carga una clave pública PEM desde disco. También acepta una clave
+// privada, en cuyo caso solo se conserva su parte pública.
+func LoadPublicKey(path, algorithm, id string) (*SigningKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var public interface{}
+	switch block.Type {
+	case "PUBLIC KEY":
+		public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		public, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, loadErr := LoadPrivateKey(path, algorithm, id)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return NewVerificationKey(key.ID, algorithm, key.public)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo la clave pública %s: %v", path, err)
+	}
+
+	return NewVerificationKey(id, algorithm, public)
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo la clave %s: %v", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("el archivo %s no contiene un bloque PEM", path)
+	}
+	return block, nil
+}
+
+// KeyManager administra la clave de firma actual y las claves anteriores que
+// se siguen aceptando durante una ventana de rotación
+type KeyManager struct {
+	current  *SigningKey
+	previous []*SigningKey
+	now      func() time.Time
+}
+
+// NewKeyManager crea un KeyManager con la clave de firma actual y las claves anteriores
+func NewKeyManager(current *SigningKey, previous ...*SigningKey) (*KeyManager, error) {
+	if current == nil || !current.CanSign() {
+		return nil, errors.New("la clave actual debe permitir firmar")
+	}
+
+	seen := map[string]bool{current.ID: true}
+	for _, key := range previous {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("identificador de clave duplicado: %s", key.ID)
+		}
+		seen[key.ID] = true
+	}
+
+	return &KeyManager{
+		current:  current,
+		previous: previous,
+		now:      time.Now,
+	}, nil
+}
+
+// Current retorna la clave usada para firmar nuevos tokens
+func (m *KeyManager) Current() *SigningKey {
+	return m.current
+}
+
+// VerificationKeys retorna las claves aceptadas actualmente para verificar tokens
+func (m *KeyManager) VerificationKeys() []*SigningKey {
+	now := m.now()
+	keys := []*SigningKey{m.current}
+	for _, key := range m.previous {
+		if key.isActive(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Algorithms retorna los algoritmos de las claves aceptadas
+func (m *KeyManager) Algorithms() []string {
+	var algorithms []string
+	seen := map[string]bool{}
+	for _, key := range m.VerificationKeys() {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
+
+// Keyfunc resuelve la clave de verificación de un token a partir de su cabecera kid
+func (m *KeyManager) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	// Los tokens sin kid solo se verifican con la clave actual
+	if kid == "" {
+		kid = m.current.ID
+	}
+
+	for _, key := range m.VerificationKeys() {
+		if key.ID != kid {
+			continue
+		}
+		// Evitar ataques de confusión de algoritmo
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("algoritmo %s no corresponde a la clave %s", token.Method.Alg(), kid)
+		}
+		return key.public, nil
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// JWKS retorna el conjunto de claves públicas aceptadas. Las claves simétricas nunca se publican.
+func (m *KeyManager) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range m.VerificationKeys() {
+		if key.IsSymmetric() {
+			continue
+		}
+		jwk, err := key.JWK()
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
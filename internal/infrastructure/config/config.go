@@ -1,7 +1,10 @@
 package config
 
 import (
-	"os"
+	"context"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/infrastructure/i18n"
 
 	"gorm.io/gorm"
 )
@@ -9,27 +12,116 @@ import (
 type Config struct {
 	Environment string
 	Port        string
+	Server      *ServerConfig
 	Database    *DatabaseConfig
+	JWT         *JWTConfig
+	Admin       *AdminConfig
+	Health      *HealthConfig
+	Events      *EventsConfig
 	DB          *gorm.DB
+	Tokens      *auth.TokenManager
+	Translator  *i18n.Translator
 }
 
+// NewConfig carga la configuración de las variables de entorno y del fichero CONFIG_FILE,
+// si está definido, y conecta con la base de datos, reintentando mientras arranca
 func NewConfig() (*Config, error) {
+	source, err := NewSource(SourceOptions{})
+	if err != nil {
+		return nil, err
+	}
+	config, err := LoadConfig(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ConnectDatabase(context.Background()); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadConfig carga y valida la configuración sin conectar con la base de datos. Si hay valores
+// inválidos devuelve un *ValidationError con todos los problemas a la vez.
+func LoadConfig(source *Source) (*Config, error) {
 	config := &Config{
-		Environment: os.Getenv("ENV"),
-		Port:        os.Getenv("PORT"),
-		Database:    NewDatabaseConfig(),
+		Environment: source.String("ENV"),
+		Port:        source.String("PORT"),
+		Server:      loadServerConfig(source),
+		Database:    loadDatabaseConfig(source),
+		JWT:         loadJWTConfig(source),
+		Admin:       loadAdminConfig(source),
+		Health:      loadHealthConfig(source),
+		Events:      loadEventsConfig(source),
+	}
+	for _, problem := range config.JWT.problems() {
+		source.Problem("%s", problem)
 	}
 
-	// Conectar a la base de datos
-	db, err := config.Database.Connect()
+	// Preparar el catálogo de mensajes con el idioma por defecto configurado
+	translator, err := i18n.NewTranslator(source.String("DEFAULT_LOCALE"))
+	if err != nil {
+		source.Problem("DEFAULT_LOCALE: %v", err)
+	}
+	config.Translator = translator
+
+	// Validar toda la configuración antes de leer las claves o tocar la base de datos
+	if err := source.Err(); err != nil {
+		return nil, err
+	}
+	tokens, err := config.JWT.NewTokenManager()
 	if err != nil {
 		return nil, err
 	}
-	config.DB = db
+	config.Tokens = tokens
 
 	return config, nil
 }
 
+// ConnectDatabase conecta con la base de datos según Database.ConnectRetry y guarda la
+// conexión en DB. Cancelar ctx interrumpe la espera entre intentos.
+func (c *Config) ConnectDatabase(ctx context.Context) error {
+	db, err := c.Database.ConnectWithRetry(ctx)
+	if err != nil {
+		return err
+	}
+	c.DB = db
+	return nil
+}
+
+// AdminConfig define el administrador que se crea al arrancar si aún no existe
+type AdminConfig struct {
+	Email    string
+	Name     string
+	Password string
+}
+
+func NewAdminConfig() *AdminConfig {
+	return loadAdminConfig(NewEnvSource())
+}
+
+func loadAdminConfig(source *Source) *AdminConfig {
+	return &AdminConfig{
+		Email:    source.String("ADMIN_EMAIL"),
+		Name:     source.String("ADMIN_NAME"),
+		Password: source.String("ADMIN_PASSWORD"),
+	}
+}
+
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production" || c.Environment == "prod"
 }
+
+func (c *Config) IsDevelopment() bool {
+	return isDevelopment(c.Environment)
+}
+
+// isDevelopment indica si el entorno permite valores inseguros de desarrollo.
+// Un entorno vacío se considera productivo para fallar de forma segura.
+func isDevelopment(environment string) bool {
+	switch environment {
+	case "dev", "development", "local", "test":
+		return true
+	default:
+		return false
+	}
+}
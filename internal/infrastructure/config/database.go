@@ -1,15 +1,32 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io/fs"
+	"log"
+	"net"
+	"net/url"
+	"time"
 
-	"go-hexagonal-template/internal/modules/user/domain/model"
-
-	"gorm.io/gorm"
+	"go-hexagonal-template/internal/infrastructure/migrations"
+	"go-hexagonal-template/internal/infrastructure/retry"
+	"go-hexagonal-template/internal/infrastructure/transaction"
 
+	"github.com/glebarez/sqlite"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Motores de base de datos que puede usar el servicio
+const (
+	DriverPostgres = migrations.DriverPostgres
+	DriverMySQL    = migrations.DriverMySQL
+	DriverSQLite   = migrations.DriverSQLite
 )
 
 type DatabaseConfig struct {
@@ -21,28 +38,183 @@ type DatabaseConfig struct {
 	DBName      string
 	SSLMode     string
 	LogLevel    string
-	AutoMigrate bool
-	RefreshDB   bool
+	// Driver elige el motor: postgres, mysql o sqlite. Con sqlite, DBName es la ruta del fichero.
+	Driver string
+	// MigrateOnStart aplica las migraciones pendientes al arrancar el servidor
+	MigrateOnStart bool
+	// QueryTimeout limita la duración de cada sentencia; 0 la deja sin límite propio
+	QueryTimeout time.Duration
+	// Ajustes del pool de conexiones, aplicados al primario y a cada réplica. MaxOpenConns
+	// y las duraciones a 0 quitan el límite; MaxIdleConns a 0 usa el valor de database/sql.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// ReplicaDSNs son réplicas de lectura con el formato de DSN del driver. Solo reciben
+	// las consultas que lo piden con transaction.ReadDB; el resto va al primario.
+	ReplicaDSNs []string
+	// ConnectRetry reintenta la conexión inicial mientras la base de datos arranca
+	ConnectRetry retry.Policy
+	// ConnectAttemptTimeout limita cada intento de conexión para que un servidor que no
+	// responde no agote DB_CONNECT_MAX_WAIT en un solo intento; 0 lo deja sin límite propio
+	ConnectAttemptTimeout time.Duration
+	// ConnectInBackground arranca el servidor HTTP sin esperar a la base de datos;
+	// readiness informa que la instancia no está lista hasta que conecta
+	ConnectInBackground bool
 }
 
-func NewDatabaseConfig() *DatabaseConfig {
-	return &DatabaseConfig{
-		Environment: os.Getenv("ENV"),
-		Host:        os.Getenv("DB_HOST"),
-		Port:        os.Getenv("DB_PORT"),
-		User:        os.Getenv("DB_USER"),
-		Password:    os.Getenv("DB_PASSWORD"),
-		DBName:      os.Getenv("DB_NAME"),
-		SSLMode:     os.Getenv("DB_SSL_MODE"),
-		LogLevel:    os.Getenv("GORM_LOG_LEVEL"),
-		AutoMigrate: os.Getenv("GORM_AUTO_MIGRATE") == "true",
-		RefreshDB:   os.Getenv("GORM_REFRESH_DB") == "true",
+func NewDatabaseConfig() (*DatabaseConfig, error) {
+	return LoadDatabaseConfig(NewEnvSource())
+}
+
+// LoadDatabaseConfig lee la configuración de la base de datos de source; la usan los comandos
+// que no necesitan el resto de la configuración, como server migrate
+func LoadDatabaseConfig(source *Source) (*DatabaseConfig, error) {
+	config := loadDatabaseConfig(source)
+	if err := source.Err(); err != nil {
+		return nil, err
 	}
+	return config, nil
 }
 
+func loadDatabaseConfig(source *Source) *DatabaseConfig {
+	config := &DatabaseConfig{
+		Environment:     source.String("ENV"),
+		Driver:          source.String("DB_DRIVER"),
+		Host:            source.String("DB_HOST"),
+		Port:            source.String("DB_PORT"),
+		User:            source.String("DB_USER"),
+		Password:        source.String("DB_PASSWORD"),
+		DBName:          source.String("DB_NAME"),
+		SSLMode:         source.String("DB_SSL_MODE"),
+		LogLevel:        source.String("GORM_LOG_LEVEL"),
+		MigrateOnStart:  source.Bool("DB_MIGRATE_ON_START"),
+		QueryTimeout:    source.Duration("DB_QUERY_TIMEOUT"),
+		MaxOpenConns:    source.Int("DB_MAX_OPEN_CONNS"),
+		MaxIdleConns:    source.Int("DB_MAX_IDLE_CONNS"),
+		ConnMaxLifetime: source.Duration("DB_CONN_MAX_LIFETIME"),
+		ConnMaxIdleTime: source.Duration("DB_CONN_MAX_IDLE_TIME"),
+		ReplicaDSNs:     source.List("DB_REPLICA_DSNS"),
+		ConnectRetry: retry.Policy{
+			MaxWait:        source.Duration("DB_CONNECT_MAX_WAIT"),
+			InitialBackoff: source.Duration("DB_CONNECT_INITIAL_BACKOFF"),
+			MaxBackoff:     source.Duration("DB_CONNECT_MAX_BACKOFF"),
+		},
+		ConnectAttemptTimeout: source.Duration("DB_CONNECT_ATTEMPT_TIMEOUT"),
+		ConnectInBackground:   source.Bool("DB_CONNECT_IN_BACKGROUND"),
+	}
+
+	switch config.Driver {
+	case DriverPostgres, DriverMySQL, DriverSQLite:
+	default:
+		source.Problem("DB_DRIVER inválido %q: usa postgres, mysql o sqlite", config.Driver)
+	}
+	if config.QueryTimeout < 0 {
+		source.Problem("DB_QUERY_TIMEOUT no puede ser negativo")
+	}
+
+	if config.MaxOpenConns < 0 {
+		source.Problem("DB_MAX_OPEN_CONNS no puede ser negativo")
+	}
+	if config.MaxIdleConns < 0 {
+		source.Problem("DB_MAX_IDLE_CONNS no puede ser negativo")
+	}
+	if config.MaxOpenConns > 0 && config.MaxIdleConns > config.MaxOpenConns {
+		source.Problem("DB_MAX_IDLE_CONNS no puede superar DB_MAX_OPEN_CONNS")
+	}
+	if config.ConnMaxLifetime < 0 {
+		source.Problem("DB_CONN_MAX_LIFETIME no puede ser negativo")
+	}
+	if config.ConnMaxIdleTime < 0 {
+		source.Problem("DB_CONN_MAX_IDLE_TIME no puede ser negativo")
+	}
+
+	if config.ConnectRetry.MaxWait < 0 {
+		source.Problem("DB_CONNECT_MAX_WAIT no puede ser negativo")
+	}
+	if config.ConnectRetry.InitialBackoff <= 0 {
+		source.Problem("DB_CONNECT_INITIAL_BACKOFF debe ser positivo")
+	} else if config.ConnectRetry.MaxBackoff < config.ConnectRetry.InitialBackoff {
+		source.Problem("DB_CONNECT_MAX_BACKOFF no puede ser menor que DB_CONNECT_INITIAL_BACKOFF")
+	}
+	if config.ConnectAttemptTimeout < 0 {
+		source.Problem("DB_CONNECT_ATTEMPT_TIMEOUT no puede ser negativo")
+	}
+
+	return config
+}
+
+// GetDSN construye la cadena de conexión en el formato del driver configurado
 func (c *DatabaseConfig) GetDSN() string {
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+	switch c.Driver {
+	case DriverMySQL:
+		return c.mysqlDSN()
+	case DriverSQLite:
+		return c.sqliteDSN()
+	default:
+		return c.postgresDSN()
+	}
+}
+
+// postgresDSN traslada DB_CONNECT_ATTEMPT_TIMEOUT a connect_timeout, que el driver
+// solo admite en segundos enteros
+func (c *DatabaseConfig) postgresDSN() string {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
+	if c.ConnectAttemptTimeout > 0 {
+		seconds := int64((c.ConnectAttemptTimeout + time.Second - 1) / time.Second)
+		dsn += fmt.Sprintf(" connect_timeout=%d", seconds)
+	}
+	return dsn
+}
+
+// mysqlDSN lee las fechas en UTC y permite varias sentencias por Exec, que necesitan
+// los scripts de migración. DB_SSL_MODE se traduce al parámetro tls del driver.
+func (c *DatabaseConfig) mysqlDSN() string {
+	dsn := mysqldriver.NewConfig()
+	dsn.User = c.User
+	dsn.Passwd = c.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(c.Host, c.Port)
+	dsn.DBName = c.DBName
+	dsn.ParseTime = true
+	dsn.Loc = time.UTC
+	dsn.MultiStatements = true
+	dsn.Timeout = c.ConnectAttemptTimeout
+	dsn.Params = map[string]string{"charset": "utf8mb4"}
+	switch c.SSLMode {
+	case "require":
+		dsn.TLSConfig = "skip-verify"
+	case "verify-ca", "verify-full":
+		dsn.TLSConfig = "true"
+	}
+	return dsn.FormatDSN()
+}
+
+// sqliteDSN activa las claves foráneas, que SQLite desactiva por defecto, y espera a
+// que se libere el fichero en lugar de fallar cuando otra conexión está escribiendo
+func (c *DatabaseConfig) sqliteDSN() string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	return c.DBName + "?" + params.Encode()
+}
+
+// Dialector devuelve el dialecto de GORM del driver configurado
+func (c *DatabaseConfig) Dialector() gorm.Dialector {
+	return c.dialector(c.GetDSN())
+}
+
+func (c *DatabaseConfig) dialector(dsn string) gorm.Dialector {
+	switch c.Driver {
+	case DriverMySQL:
+		return mysql.Open(dsn)
+	case DriverSQLite:
+		return sqlite.Open(dsn)
+	default:
+		return postgres.Open(dsn)
+	}
 }
 
 func (c *DatabaseConfig) getLogLevel() logger.LogLevel {
@@ -60,29 +232,107 @@ func (c *DatabaseConfig) getLogLevel() logger.LogLevel {
 	}
 }
 
-func (c *DatabaseConfig) Connect() (*gorm.DB, error) {
+// Connect abre la conexión con la base de datos y comprueba que responde antes de que
+// venza ctx. Si algún paso falla cierra la conexión abierta.
+func (c *DatabaseConfig) Connect(ctx context.Context) (db *gorm.DB, err error) {
+	// GORM haría ping sin contexto al abrir; se hace después con ctx para poder cortarlo
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(c.getLogLevel()),
+		Logger:               logger.Default.LogMode(c.getLogLevel()),
+		DisableAutomaticPing: true,
 	}
 
-	db, err := gorm.Open(postgres.Open(c.GetDSN()), gormConfig)
+	db, err = gorm.Open(c.Dialector(), gormConfig)
 	if err != nil {
 		return nil, err
 	}
-
-	// Solo en desarrollo y si está configurado, hacer refresh de las tablas
-	if c.Environment == "dev" && c.RefreshDB {
-		if err := db.Migrator().DropTable(&model.User{}); err != nil {
-			return nil, fmt.Errorf("error eliminando tablas: %v", err)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			sqlDB.Close()
+		}
+	}()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, err
+	}
+	if c.QueryTimeout > 0 {
+		if err := db.Use(NewQueryTimeoutPlugin(c.QueryTimeout)); err != nil {
+			return nil, err
 		}
 	}
 
-	// Auto-migrar las tablas si está configurado y si no estamos en producción
-	if c.AutoMigrate && c.Environment != "prod" {
-		if err := db.AutoMigrate(&model.User{}); err != nil {
-			return nil, fmt.Errorf("error auto-migrando tablas: %v", err)
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	if c.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(c.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
+	if len(c.ReplicaDSNs) > 0 {
+		replicas := make([]gorm.Dialector, 0, len(c.ReplicaDSNs))
+		for _, dsn := range c.ReplicaDSNs {
+			replicas = append(replicas, c.dialector(dsn))
+		}
+		// El resolver tiene nombre para que las consultas sin transaction.ReadDB sigan en el
+		// primario y lean lo que acaban de escribir
+		resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas}, transaction.ReadReplicas).
+			SetMaxOpenConns(c.MaxOpenConns).
+			SetConnMaxLifetime(c.ConnMaxLifetime).
+			SetConnMaxIdleTime(c.ConnMaxIdleTime)
+		if c.MaxIdleConns > 0 {
+			resolver.SetMaxIdleConns(c.MaxIdleConns)
+		}
+		if err := db.Use(resolver); err != nil {
+			return nil, fmt.Errorf("error conectando a las réplicas: %w", err)
 		}
 	}
 
 	return db, nil
 }
+
+// ConnectWithRetry llama a Connect hasta que la base de datos acepta conexiones, con espera
+// exponencial y jitter entre intentos, durante como mucho ConnectRetry.MaxWait. Cada intento
+// dura como mucho ConnectAttemptTimeout y se registra en el log si falla.
+func (c *DatabaseConfig) ConnectWithRetry(ctx context.Context) (*gorm.DB, error) {
+	var db *gorm.DB
+	err := retry.Do(ctx, c.ConnectRetry, func(ctx context.Context) error {
+		if c.ConnectAttemptTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.ConnectAttemptTimeout)
+			defer cancel()
+		}
+		var err error
+		db, err = c.Connect(ctx)
+		return err
+	}, func(attempt int, err error, wait time.Duration) {
+		log.Printf("Intento %d de conexión a la base de datos fallido: %v; reintentando en %s", attempt, err, wait.Round(time.Millisecond))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error conectando a la base de datos: %w", err)
+	}
+	return db, nil
+}
+
+// Migrate aplica las migraciones pendientes de los orígenes indicados, normalmente uno por
+// módulo. El bloqueo de migraciones evita que varias réplicas que arrancan a la vez migren en paralelo.
+func (c *DatabaseConfig) Migrate(ctx context.Context, db *gorm.DB, sources ...fs.FS) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewSQLMigrator(sqlDB, c.Driver, sources...)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		log.Printf("Migración aplicada: %d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("error aplicando migraciones: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"time"
+)
+
+// Destinos a los que el relay del outbox puede publicar los eventos
+const (
+	EventPublisherBus     = "bus"
+	EventPublisherWebhook = "webhook"
+	EventPublisherStdout  = "stdout"
+	EventPublisherFile    = "file"
+)
+
+// EventsConfig define a dónde se publican los eventos del outbox y con qué frecuencia
+type EventsConfig struct {
+	Publisher      string
+	WebhookURL     string
+	WebhookTimeout time.Duration
+	FilePath       string
+	PollInterval   time.Duration
+	BatchSize      int
+	// ClaimTimeout es el tiempo que un lote queda reservado para la instancia que lo publica
+	ClaimTimeout time.Duration
+}
+
+func NewEventsConfig() (*EventsConfig, error) {
+	source := NewEnvSource()
+	config := loadEventsConfig(source)
+	if err := source.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func loadEventsConfig(source *Source) *EventsConfig {
+	config := &EventsConfig{
+		Publisher:      source.String("EVENTS_PUBLISHER"),
+		WebhookURL:     source.String("EVENTS_WEBHOOK_URL"),
+		WebhookTimeout: source.Duration("EVENTS_WEBHOOK_TIMEOUT"),
+		FilePath:       source.String("EVENTS_FILE_PATH"),
+		PollInterval:   source.Duration("OUTBOX_POLL_INTERVAL"),
+		BatchSize:      source.Int("OUTBOX_BATCH_SIZE"),
+		ClaimTimeout:   source.Duration("OUTBOX_CLAIM_TIMEOUT"),
+	}
+
+	switch config.Publisher {
+	case EventPublisherBus, EventPublisherStdout, EventPublisherFile:
+	case EventPublisherWebhook:
+		if config.WebhookURL == "" {
+			source.Problem("EVENTS_WEBHOOK_URL es obligatorio con EVENTS_PUBLISHER=webhook")
+		}
+	default:
+		source.Problem("EVENTS_PUBLISHER inválido %q: usa bus, webhook, stdout o file", config.Publisher)
+	}
+	if config.WebhookTimeout <= 0 {
+		source.Problem("EVENTS_WEBHOOK_TIMEOUT debe ser positivo")
+	}
+	if config.PollInterval <= 0 {
+		source.Problem("OUTBOX_POLL_INTERVAL debe ser positivo")
+	}
+	if config.BatchSize <= 0 {
+		source.Problem("OUTBOX_BATCH_SIZE debe ser positivo")
+	}
+	if config.ClaimTimeout <= 0 {
+		source.Problem("OUTBOX_CLAIM_TIMEOUT debe ser positivo")
+	}
+
+	return config
+}
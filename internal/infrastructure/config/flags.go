@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// DefaultEnvFile es el fichero .env que se lee si existe y no se indica otro
+const DefaultEnvFile = ".env"
+
+// Flags son los flags de configuración comunes a los comandos del servicio
+type Flags struct {
+	File        string
+	EnvFile     string
+	PrintConfig bool
+
+	flagSet *flag.FlagSet
+	// keys relaciona el nombre de cada flag de opción con su clave
+	keys map[string]string
+}
+
+// RegisterFlags registra en flagSet --config, --env-file, --print-config y un flag por cada
+// opción, con el nombre de la variable de entorno en minúsculas y guiones: DB_HOST es --db-host
+func RegisterFlags(flagSet *flag.FlagSet) *Flags {
+	flags := &Flags{flagSet: flagSet, keys: make(map[string]string, len(settings))}
+	flagSet.StringVar(&flags.File, "config", "", "fichero de configuración YAML o TOML (o CONFIG_FILE)")
+	flagSet.StringVar(&flags.EnvFile, "env-file", DefaultEnvFile, "fichero .env; se ignora si no existe y no se indica")
+	flagSet.BoolVar(&flags.PrintConfig, "print-config", false, "muestra la configuración efectiva, con los secretos ocultos, y termina")
+	for _, setting := range settings {
+		name := FlagName(setting.key)
+		flagSet.String(name, "", setting.description)
+		flags.keys[name] = setting.key
+	}
+	return flags
+}
+
+// FlagName devuelve el nombre del flag de una clave
+func FlagName(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+}
+
+// Source crea el Source con los flags indicados en la línea de comandos. Debe llamarse
+// después de parsear el FlagSet.
+func (f *Flags) Source() (*Source, error) {
+	options := SourceOptions{File: f.File, EnvFile: f.EnvFile, Flags: map[string]string{}}
+	envFileSet := false
+	f.flagSet.Visit(func(fl *flag.Flag) {
+		if key, ok := f.keys[fl.Name]; ok {
+			options.Flags[key] = fl.Value.String()
+		}
+		if fl.Name == "env-file" {
+			envFileSet = true
+		}
+	})
+
+	// El .env por defecto es opcional; uno indicado de forma explícita debe existir
+	if !envFileSet {
+		if _, err := os.Stat(options.EnvFile); errors.Is(err, fs.ErrNotExist) {
+			options.EnvFile = ""
+		}
+	}
+	return NewSource(options)
+}
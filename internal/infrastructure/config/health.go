@@ -0,0 +1,49 @@
+package config
+
+import (
+	"time"
+)
+
+// Valores por defecto de las sondas
+const (
+	DefaultHealthCheckTimeout = 2 * time.Second
+	DefaultHealthCacheTTL     = 5 * time.Second
+)
+
+// HealthConfig define los plazos y umbrales de las sondas de liveness y readiness
+type HealthConfig struct {
+	CheckTimeout  time.Duration
+	CacheTTL      time.Duration
+	DiskPath      string
+	DiskMinFreeMB uint64
+	// DBStatsEnabled publica /dbstats; está desactivado porque la ruta no exige autenticación
+	DBStatsEnabled bool
+}
+
+func NewHealthConfig() (*HealthConfig, error) {
+	source := NewEnvSource()
+	config := loadHealthConfig(source)
+	if err := source.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func loadHealthConfig(source *Source) *HealthConfig {
+	config := &HealthConfig{
+		CheckTimeout:   source.Duration("HEALTH_CHECK_TIMEOUT"),
+		CacheTTL:       source.Duration("HEALTH_CACHE_TTL"),
+		DiskPath:       source.String("HEALTH_DISK_PATH"),
+		DiskMinFreeMB:  source.Uint("HEALTH_DISK_MIN_FREE_MB"),
+		DBStatsEnabled: source.Bool("HEALTH_DB_STATS_ENABLED"),
+	}
+
+	if config.CheckTimeout <= 0 {
+		source.Problem("HEALTH_CHECK_TIMEOUT debe ser positivo")
+	}
+	if config.CacheTTL < 0 {
+		source.Problem("HEALTH_CACHE_TTL no puede ser negativo")
+	}
+
+	return config
+}
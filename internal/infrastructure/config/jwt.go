@@ -0,0 +1,220 @@
+package config
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+)
+
+// MinJWTSecretLength es la longitud mínima aceptada para el secreto HS256
+const MinJWTSecretLength = 32
+
+// weakJWTSecrets son valores de ejemplo que nunca deben usarse fuera de desarrollo
+var weakJWTSecrets = []string{
+	"default-secret-key",
+	"your-secret-key",
+	"secret",
+	"changeme",
+}
+
+type JWTConfig struct {
+	Environment     string
+	Secret          string
+	Issuer          string
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	Leeway          time.Duration
+
+	SigningAlgorithm         string
+	PrivateKeyPath           string
+	KeyID                    string
+	PreviousPublicKeyPath    string
+	PreviousKeyID            string
+	PreviousSigningAlgorithm string
+	RotationWindow           time.Duration
+}
+
+// Emisor y audiencia que se usan en desarrollo si no se configuran
+const (
+	developmentJWTIssuer   = "go-hexagonal-template"
+	developmentJWTAudience = "go-hexagonal-template-api"
+)
+
+func NewJWTConfig() (*JWTConfig, error) {
+	source := NewEnvSource()
+	config := loadJWTConfig(source)
+	if err := source.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func loadJWTConfig(source *Source) *JWTConfig {
+	config := &JWTConfig{
+		Environment:              source.String("ENV"),
+		Secret:                   source.String("JWT_SECRET_KEY"),
+		Issuer:                   source.String("JWT_ISSUER"),
+		Audience:                 source.String("JWT_AUDIENCE"),
+		AccessTokenTTL:           source.Duration("JWT_ACCESS_TOKEN_TTL"),
+		RefreshTokenTTL:          source.Duration("JWT_REFRESH_TOKEN_TTL"),
+		Leeway:                   source.Duration("JWT_LEEWAY"),
+		SigningAlgorithm:         source.String("JWT_SIGNING_ALGORITHM"),
+		PrivateKeyPath:           source.String("JWT_PRIVATE_KEY_PATH"),
+		KeyID:                    source.String("JWT_KEY_ID"),
+		PreviousPublicKeyPath:    source.String("JWT_PREVIOUS_PUBLIC_KEY_PATH"),
+		PreviousKeyID:            source.String("JWT_PREVIOUS_KEY_ID"),
+		PreviousSigningAlgorithm: source.String("JWT_PREVIOUS_SIGNING_ALGORITHM"),
+		RotationWindow:           source.Duration("JWT_ROTATION_WINDOW"),
+	}
+
+	// Solo en desarrollo se usan un emisor y una audiencia por defecto; en producción deben
+	// configurarse para que los tokens de otros despliegues no sean válidos aquí
+	if isDevelopment(config.Environment) {
+		if config.Issuer == "" {
+			config.Issuer = developmentJWTIssuer
+		}
+		if config.Audience == "" {
+			config.Audience = developmentJWTAudience
+		}
+	}
+
+	// Por defecto la clave anterior se acepta mientras puedan existir tokens firmados con ella
+	if config.RotationWindow == 0 {
+		config.RotationWindow = config.AccessTokenTTL
+	}
+
+	if config.PreviousSigningAlgorithm == "" {
+		config.PreviousSigningAlgorithm = config.SigningAlgorithm
+	}
+
+	return config
+}
+
+// Validate verifica la configuración. Fuera de desarrollo un secreto ausente o débil es un error.
+func (c *JWTConfig) Validate() error {
+	if problems := c.problems(); len(problems) > 0 {
+		return errors.New("configuración JWT inválida: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// problems describe cada regla que incumple la configuración
+func (c *JWTConfig) problems() []string {
+	var problems []string
+
+	if c.AccessTokenTTL <= 0 {
+		problems = append(problems, "JWT_ACCESS_TOKEN_TTL debe ser positivo")
+	}
+	if c.RefreshTokenTTL < c.AccessTokenTTL {
+		problems = append(problems, "JWT_REFRESH_TOKEN_TTL no puede ser menor que JWT_ACCESS_TOKEN_TTL")
+	}
+	if c.Leeway < 0 {
+		problems = append(problems, "JWT_LEEWAY no puede ser negativo")
+	}
+	if c.Issuer == "" {
+		problems = append(problems, "JWT_ISSUER es requerido")
+	}
+	if c.Audience == "" {
+		problems = append(problems, "JWT_AUDIENCE es requerido")
+	}
+
+	switch c.SigningAlgorithm {
+	case auth.AlgorithmHS256:
+		if problem := c.secretProblem(); problem != "" && !isDevelopment(c.Environment) {
+			problems = append(problems, problem)
+		}
+	case auth.AlgorithmRS256, auth.AlgorithmES256, auth.AlgorithmEdDSA:
+		if c.PrivateKeyPath == "" {
+			problems = append(problems, fmt.Sprintf("JWT_PRIVATE_KEY_PATH es requerido para %s", c.SigningAlgorithm))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("JWT_SIGNING_ALGORITHM no soportado: %s", c.SigningAlgorithm))
+	}
+	return problems
+}
+
+// secretProblem describe por qué el secreto HS256 no es aceptable, o retorna una cadena vacía
+func (c *JWTConfig) secretProblem() string {
+	if c.Secret == "" {
+		return "JWT_SECRET_KEY es requerido"
+	}
+	for _, weak := range weakJWTSecrets {
+		if strings.EqualFold(c.Secret, weak) {
+			return "JWT_SECRET_KEY usa un valor de ejemplo"
+		}
+	}
+	if len(c.Secret) < MinJWTSecretLength {
+		return fmt.Sprintf("JWT_SECRET_KEY debe tener al menos %d caracteres", MinJWTSecretLength)
+	}
+	return ""
+}
+
+// NewTokenManager carga las claves de firma y crea el TokenManager
+func (c *JWTConfig) NewTokenManager() (*auth.TokenManager, error) {
+	var current *auth.SigningKey
+	if c.SigningAlgorithm == auth.AlgorithmHS256 {
+		secret, err := c.hmacSecret()
+		if err != nil {
+			return nil, err
+		}
+		current = auth.NewHMACKey(c.KeyID, secret)
+	} else {
+		var err error
+		current, err = auth.LoadPrivateKey(c.PrivateKeyPath, c.SigningAlgorithm, c.KeyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Clave anterior aceptada durante la ventana de rotación
+	var previous []*auth.SigningKey
+	if c.PreviousPublicKeyPath != "" {
+		key, err := auth.LoadPublicKey(c.PreviousPublicKeyPath, c.PreviousSigningAlgorithm, c.PreviousKeyID)
+		if err != nil {
+			return nil, err
+		}
+		key.ExpiresAt = time.Now().Add(c.RotationWindow)
+		previous = append(previous, key)
+	}
+
+	keyManager, err := auth.NewKeyManager(current, previous...)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewTokenManager(keyManager, auth.TokenOptions{
+		Issuer:          c.Issuer,
+		Audience:        c.Audience,
+		AccessTokenTTL:  c.AccessTokenTTL,
+		RefreshTokenTTL: c.RefreshTokenTTL,
+		Leeway:          c.Leeway,
+	}), nil
+}
+
+// hmacSecret retorna el secreto HS256. En desarrollo, si no hay uno configurado,
+// se genera uno aleatorio en lugar de usar un valor fijo conocido.
+func (c *JWTConfig) hmacSecret() ([]byte, error) {
+	if problem := c.secretProblem(); problem != "" {
+		if !isDevelopment(c.Environment) {
+			return nil, errors.New(problem)
+		}
+		if c.Secret != "" {
+			log.Printf("ADVERTENCIA: %s; solo se acepta en desarrollo", problem)
+			return []byte(c.Secret), nil
+		}
+
+		log.Printf("ADVERTENCIA: JWT_SECRET_KEY no configurado; se usará un secreto temporal y los tokens no sobrevivirán a un reinicio")
+		secret := make([]byte, MinJWTSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+	return []byte(c.Secret), nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryTimeoutKey guarda en la sentencia el contexto original y la cancelación del plazo
+const queryTimeoutKey = "query_timeout:state"
+
+type queryTimeoutState struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// queryTimeoutPlugin limita la duración de cada sentencia. El plazo se suma al del
+// contexto de la petición: si la petición vence antes, manda su plazo.
+type queryTimeoutPlugin struct {
+	timeout time.Duration
+}
+
+// NewQueryTimeoutPlugin crea el plugin de GORM que aplica timeout a cada sentencia
+func NewQueryTimeoutPlugin(timeout time.Duration) gorm.Plugin {
+	return &queryTimeoutPlugin{timeout: timeout}
+}
+
+func (p *queryTimeoutPlugin) Name() string {
+	return "query_timeout"
+}
+
+// Initialize registra el plazo en create, query, update, delete y raw. Row y Rows quedan
+// fuera porque el llamador lee el resultado después de que terminen los callbacks.
+func (p *queryTimeoutPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("*").Register("query_timeout:start_create", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("*").Register("query_timeout:stop_create", p.stop); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("*").Register("query_timeout:start_query", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("*").Register("query_timeout:stop_query", p.stop); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("*").Register("query_timeout:start_update", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("*").Register("query_timeout:stop_update", p.stop); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("*").Register("query_timeout:start_delete", p.start); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("*").Register("query_timeout:stop_delete", p.stop); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("*").Register("query_timeout:start_raw", p.start); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("*").Register("query_timeout:stop_raw", p.stop)
+}
+
+func (p *queryTimeoutPlugin) start(db *gorm.DB) {
+	parent := db.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if deadline, ok := parent.Deadline(); ok && time.Until(deadline) <= p.timeout {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(parent, p.timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(queryTimeoutKey, queryTimeoutState{parent: parent, cancel: cancel})
+}
+
+// stop libera el plazo y restaura el contexto original, porque la sentencia puede reutilizarse
+func (p *queryTimeoutPlugin) stop(db *gorm.DB) {
+	value, ok := db.InstanceGet(queryTimeoutKey)
+	if !ok {
+		return
+	}
+	state := value.(queryTimeoutState)
+	state.cancel()
+	db.Statement.Context = state.parent
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"time"
+)
+
+// ServerConfig define los plazos del servidor HTTP y del apagado ordenado
+type ServerConfig struct {
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	DrainPeriod     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+func NewServerConfig() (*ServerConfig, error) {
+	source := NewEnvSource()
+	config := loadServerConfig(source)
+	if err := source.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func loadServerConfig(source *Source) *ServerConfig {
+	config := &ServerConfig{}
+
+	durations := []struct {
+		name   string
+		target *time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", &config.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", &config.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", &config.IdleTimeout},
+		{"SHUTDOWN_DRAIN_PERIOD", &config.DrainPeriod},
+		{"SHUTDOWN_TIMEOUT", &config.ShutdownTimeout},
+	}
+	for _, d := range durations {
+		value := source.Duration(d.name)
+		if value < 0 {
+			source.Problem("%s no puede ser negativo", d.name)
+		}
+		*d.target = value
+	}
+
+	// El drenaje forma parte del apagado, así que debe dejar tiempo para cerrar las conexiones
+	if config.ShutdownTimeout <= config.DrainPeriod {
+		source.Problem("SHUTDOWN_TIMEOUT debe ser mayor que SHUTDOWN_DRAIN_PERIOD")
+	}
+
+	return config
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/infrastructure/i18n"
+)
+
+// setting describe una opción de configuración. La clave es el nombre de la variable de
+// entorno; de ella se derivan la ruta en el fichero de configuración y el nombre del flag.
+type setting struct {
+	key         string
+	fallback    string
+	secret      bool
+	description string
+}
+
+// settings enumera todas las opciones en el orden en que se muestran con --print-config
+var settings = []setting{
+	{key: "ENV", description: "entorno: development, production..."},
+	{key: "PORT", fallback: "3000", description: "puerto HTTP"},
+	{key: "DEFAULT_LOCALE", fallback: i18n.DefaultLocale, description: "idioma por defecto de las respuestas"},
+
+	{key: "SERVER_READ_TIMEOUT", fallback: "15s", description: "tiempo máximo para leer una petición"},
+	{key: "SERVER_WRITE_TIMEOUT", fallback: "15s", description: "tiempo máximo para escribir una respuesta"},
+	{key: "SERVER_IDLE_TIMEOUT", fallback: "60s", description: "tiempo máximo de una conexión keep-alive ociosa"},
+	{key: "SHUTDOWN_DRAIN_PERIOD", fallback: "5s", description: "tiempo que readiness falla antes de cerrar el servidor"},
+	{key: "SHUTDOWN_TIMEOUT", fallback: "30s", description: "plazo máximo del apagado ordenado"},
+
+	{key: "JWT_SECRET_KEY", secret: true, description: "secreto HS256, de al menos 32 caracteres"},
+	{key: "JWT_ISSUER", description: "emisor de los tokens; requerido fuera de desarrollo"},
+	{key: "JWT_AUDIENCE", description: "audiencia de los tokens; requerida fuera de desarrollo"},
+	{key: "JWT_ACCESS_TOKEN_TTL", fallback: auth.DefaultAccessTokenTTL.String(), description: "duración del token de acceso"},
+	{key: "JWT_REFRESH_TOKEN_TTL", fallback: auth.DefaultRefreshTokenTTL.String(), description: "duración del token de refresco"},
+	{key: "JWT_LEEWAY", fallback: "30s", description: "tolerancia de reloj al validar tokens"},
+	{key: "JWT_SIGNING_ALGORITHM", fallback: auth.AlgorithmHS256, description: "algoritmo de firma: HS256, RS256, ES256 o EdDSA"},
+	{key: "JWT_PRIVATE_KEY_PATH", description: "clave privada PEM para RS256, ES256 o EdDSA"},
+	{key: "JWT_KEY_ID", description: "kid de la clave de firma"},
+	{key: "JWT_PREVIOUS_PUBLIC_KEY_PATH", description: "clave pública anterior aceptada durante la rotación"},
+	{key: "JWT_PREVIOUS_KEY_ID", description: "kid de la clave anterior"},
+	{key: "JWT_PREVIOUS_SIGNING_ALGORITHM", description: "algoritmo de la clave anterior; por defecto el actual"},
+	{key: "JWT_ROTATION_WINDOW", description: "tiempo que se acepta la clave anterior; por defecto JWT_ACCESS_TOKEN_TTL"},
+
+	{key: "ADMIN_EMAIL", description: "email del administrador inicial"},
+	{key: "ADMIN_NAME", description: "nombre del administrador inicial"},
+	{key: "ADMIN_PASSWORD", secret: true, description: "contraseña del administrador inicial"},
+
+	{key: "DB_DRIVER", fallback: DriverPostgres, description: "motor de base de datos: postgres, mysql o sqlite"},
+	{key: "DB_HOST", description: "host de la base de datos"},
+	{key: "DB_PORT", description: "puerto de la base de datos"},
+	{key: "DB_USER", description: "usuario de la base de datos"},
+	{key: "DB_PASSWORD", secret: true, description: "contraseña de la base de datos"},
+	{key: "DB_NAME", description: "nombre de la base de datos o ruta del fichero SQLite"},
+	{key: "DB_SSL_MODE", description: "modo TLS: disable, require, verify-ca o verify-full"},
+	{key: "GORM_LOG_LEVEL", description: "nivel de log de GORM: debug, info, warn, error o silent"},
+	{key: "DB_MIGRATE_ON_START", fallback: "false", description: "aplica las migraciones pendientes al arrancar"},
+	{key: "DB_QUERY_TIMEOUT", fallback: "5s", description: "duración máxima de cada sentencia SQL; 0 la desactiva"},
+	{key: "DB_MAX_OPEN_CONNS", fallback: "25", description: "máximo de conexiones abiertas; 0 sin límite"},
+	{key: "DB_MAX_IDLE_CONNS", fallback: "10", description: "conexiones ociosas que conserva el pool"},
+	{key: "DB_CONN_MAX_LIFETIME", fallback: "30m", description: "antigüedad máxima de una conexión; 0 sin límite"},
+	{key: "DB_CONN_MAX_IDLE_TIME", fallback: "5m", description: "tiempo máximo ociosa de una conexión; 0 sin límite"},
+	{key: "DB_REPLICA_DSNS", secret: true, description: "DSNs de las réplicas de lectura separados por comas"},
+	{key: "DB_CONNECT_MAX_WAIT", fallback: "1m", description: "tiempo durante el que se reintenta la conexión inicial; 0 un único intento"},
+	{key: "DB_CONNECT_INITIAL_BACKOFF", fallback: "500ms", description: "espera tras el primer intento de conexión fallido"},
+	{key: "DB_CONNECT_MAX_BACKOFF", fallback: "10s", description: "espera máxima entre intentos de conexión"},
+	{key: "DB_CONNECT_ATTEMPT_TIMEOUT", fallback: "5s", description: "duración máxima de cada intento de conexión; 0 sin límite propio"},
+	{key: "DB_CONNECT_IN_BACKGROUND", fallback: "false", description: "arranca el servidor HTTP antes de conectar con la base de datos"},
+
+	{key: "HEALTH_CHECK_TIMEOUT", fallback: DefaultHealthCheckTimeout.String(), description: "plazo de cada chequeo de readiness"},
+	{key: "HEALTH_CACHE_TTL", fallback: DefaultHealthCacheTTL.String(), description: "tiempo que se reutiliza el resultado de las sondas"},
+	{key: "HEALTH_DISK_PATH", fallback: "/", description: "ruta cuyo espacio libre se comprueba"},
+	{key: "HEALTH_DISK_MIN_FREE_MB", fallback: "100", description: "espacio libre mínimo en MB"},
+	{key: "HEALTH_DB_STATS_ENABLED", fallback: "false", description: "publica /dbstats sin autenticación"},
+
+	{key: "EVENTS_PUBLISHER", fallback: EventPublisherBus, description: "destino de los eventos: bus, webhook, stdout o file"},
+	{key: "EVENTS_WEBHOOK_URL", secret: true, description: "URL a la que se publican los eventos con webhook"},
+	{key: "EVENTS_WEBHOOK_TIMEOUT", fallback: "5s", description: "plazo de cada llamada al webhook"},
+	{key: "EVENTS_FILE_PATH", fallback: "events.log", description: "fichero en el que se escriben los eventos con file"},
+	{key: "OUTBOX_POLL_INTERVAL", fallback: "1s", description: "frecuencia con la que el relay lee el outbox"},
+	{key: "OUTBOX_BATCH_SIZE", fallback: "100", description: "eventos que publica el relay en cada lectura"},
+	{key: "OUTBOX_CLAIM_TIMEOUT", fallback: "10m", description: "tiempo que un lote queda reservado para la instancia que lo publica"},
+}
+
+// settingsByKey indexa settings por clave
+var settingsByKey = func() map[string]setting {
+	index := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		index[s.key] = s
+	}
+	return index
+}()
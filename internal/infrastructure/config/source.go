@@ -0,0 +1,283 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Orígenes de un valor, de menor a mayor prioridad
+const (
+	OriginDefault = "default"
+	OriginFile    = "file"
+	OriginEnvFile = "env-file"
+	OriginEnv     = "env"
+	OriginFlag    = "flag"
+)
+
+// redacted sustituye a los valores secretos al mostrar la configuración
+const redacted = "********"
+
+// SourceOptions indica de dónde se lee la configuración además de las variables de entorno
+type SourceOptions struct {
+	// File es un fichero YAML o TOML; si está vacío se usa la variable CONFIG_FILE
+	File string
+	// EnvFile es un fichero .env. Sus valores no sustituyen a las variables de entorno reales.
+	EnvFile string
+	// Flags son los valores indicados en la línea de comandos, por clave
+	Flags map[string]string
+}
+
+// Source resuelve cada opción combinando, de menor a mayor prioridad, el valor por defecto,
+// el fichero de configuración, el fichero .env, las variables de entorno y los flags. Los
+// valores inválidos no detienen la carga: se acumulan para informar de todos a la vez.
+type Source struct {
+	file     map[string]string
+	envFile  map[string]string
+	flags    map[string]string
+	problems []string
+}
+
+// ValidationError reúne todos los problemas encontrados al cargar la configuración
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "configuración inválida:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// NewEnvSource crea un Source que solo lee las variables de entorno y los valores por defecto
+func NewEnvSource() *Source {
+	return &Source{}
+}
+
+// NewSource lee el fichero de configuración y el fichero .env indicados. Los errores de
+// lectura se devuelven de inmediato; las claves desconocidas se acumulan como problemas.
+func NewSource(options SourceOptions) (*Source, error) {
+	source := &Source{flags: options.Flags}
+
+	if options.EnvFile != "" {
+		values, err := godotenv.Read(options.EnvFile)
+		if err != nil {
+			return nil, fmt.Errorf("error leyendo %s: %w", options.EnvFile, err)
+		}
+		source.envFile = values
+	}
+
+	file := options.File
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	if file != "" {
+		values, err := readConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+		paths := make([]string, 0, len(values))
+		for path := range values {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		source.file = make(map[string]string, len(values))
+		for _, path := range paths {
+			key := strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+			if _, ok := settingsByKey[key]; !ok {
+				source.Problem("%s: clave desconocida %q", file, path)
+				continue
+			}
+			source.file[key] = values[path]
+		}
+	}
+	return source, nil
+}
+
+// readConfigFile lee un fichero YAML o TOML y aplana sus secciones en rutas con puntos,
+// de modo que db.host corresponde a DB_HOST
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo el fichero de configuración: %w", err)
+	}
+
+	var document map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return nil, fmt.Errorf("formato de configuración no soportado %q: usa .yaml, .yml o .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", document, values)
+	return values, nil
+}
+
+func flatten(prefix string, value interface{}, values map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, child, values)
+		}
+	case []interface{}:
+		// Las listas, como DB_REPLICA_DSNS, se guardan separadas por comas igual que en el entorno
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		values[prefix] = strings.Join(items, ",")
+	case nil:
+		values[prefix] = ""
+	default:
+		values[prefix] = fmt.Sprint(v)
+	}
+}
+
+// lookup devuelve el valor de key y su origen. Un valor vacío en el entorno se considera
+// ausente, igual que las variables vacías de .env-example.
+func (s *Source) lookup(key string) (string, string) {
+	if value, ok := s.flags[key]; ok {
+		return value, OriginFlag
+	}
+	if value := os.Getenv(key); value != "" {
+		return value, OriginEnv
+	}
+	if value := s.envFile[key]; value != "" {
+		return value, OriginEnvFile
+	}
+	if value, ok := s.file[key]; ok {
+		return value, OriginFile
+	}
+	return settingsByKey[key].fallback, OriginDefault
+}
+
+// String devuelve el valor de key
+func (s *Source) String(key string) string {
+	value, _ := s.lookup(key)
+	return value
+}
+
+// Duration devuelve key como duración; un valor vacío es 0. Si el valor es inválido se
+// registra el problema y se usa el valor por defecto, para no informar de errores derivados.
+func (s *Source) Duration(key string) time.Duration {
+	return parse(s, key, func(value string) (time.Duration, error) {
+		if value == "" {
+			return 0, nil
+		}
+		return time.ParseDuration(value)
+	})
+}
+
+// Int devuelve key como entero; un valor vacío es 0
+func (s *Source) Int(key string) int {
+	return parse(s, key, func(value string) (int, error) {
+		if value == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(value)
+	})
+}
+
+// Uint devuelve key como entero sin signo; un valor vacío es 0
+func (s *Source) Uint(key string) uint64 {
+	return parse(s, key, func(value string) (uint64, error) {
+		if value == "" {
+			return 0, nil
+		}
+		return strconv.ParseUint(value, 10, 64)
+	})
+}
+
+// Bool devuelve key como booleano; un valor vacío es false
+func (s *Source) Bool(key string) bool {
+	return parse(s, key, func(value string) (bool, error) {
+		if value == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(value)
+	})
+}
+
+func parse[T any](s *Source, key string, parseValue func(value string) (T, error)) T {
+	value, origin := s.lookup(key)
+	parsed, err := parseValue(value)
+	if err != nil {
+		s.Problem("%s inválido %q (%s): %v", key, value, origin, unwrapNumError(err))
+		parsed, _ = parseValue(settingsByKey[key].fallback)
+	}
+	return parsed
+}
+
+// unwrapNumError quita de los errores de strconv la repetición de la función y el valor
+func unwrapNumError(err error) error {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		return numErr.Err
+	}
+	return err
+}
+
+// List devuelve key como lista separada por comas, sin elementos vacíos
+func (s *Source) List(key string) []string {
+	var items []string
+	for _, item := range strings.Split(s.String(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Problem registra un problema de configuración
+func (s *Source) Problem(format string, args ...interface{}) {
+	s.problems = append(s.problems, fmt.Sprintf(format, args...))
+}
+
+// Err devuelve un *ValidationError con todos los problemas registrados, o nil si no hay ninguno
+func (s *Source) Err() error {
+	if len(s.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: append([]string(nil), s.problems...)}
+}
+
+// Print escribe la configuración efectiva en formato .env, con el origen de cada valor.
+// Los secretos se ocultan.
+func (s *Source) Print(w io.Writer) error {
+	lines := make([]string, len(settings))
+	origins := make([]string, len(settings))
+	width := 0
+	for i, setting := range settings {
+		value, origin := s.lookup(setting.key)
+		if setting.secret && value != "" {
+			value = redacted
+		}
+		lines[i] = setting.key + "=" + value
+		origins[i] = origin
+		width = max(width, len(lines[i]))
+	}
+	for i, line := range lines {
+		if _, err := fmt.Fprintf(w, "%-*s  # %s\n", width, line, origins[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
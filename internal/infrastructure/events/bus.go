@@ -0,0 +1,49 @@
+// Package events contiene los adaptadores del puerto EventPublisher
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+)
+
+// Handler procesa un evento recibido del bus
+type Handler func(ctx context.Context, message model.EventMessage) error
+
+// Bus entrega los eventos a los suscriptores del mismo proceso
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe registra handler para los eventos con el nombre indicado
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish ejecuta todos los suscriptores aunque alguno falle. Si falla cualquiera el
+// evento se reintenta para todos, así que los suscriptores deben ser idempotentes.
+func (b *Bus) Publish(ctx context.Context, message model.EventMessage) error {
+	b.mu.RLock()
+	handlers := b.handlers[message.Name]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("suscriptor de %s: %w", message.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
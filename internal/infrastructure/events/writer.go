@@ -0,0 +1,29 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+)
+
+// WriterPublisher escribe cada evento como una línea JSON, por ejemplo en stdout o en
+// un fichero. Sirve para ver los eventos en local sin montar un destino real.
+type WriterPublisher struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, message model.EventMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.encoder.Encode(message)
+}
@@ -0,0 +1,98 @@
+package i18n
+
+import "sort"
+
+// Claves del catálogo que no corresponden a un código de error
+const (
+	KeyHealthy           = "healthy"
+	KeyDraining          = "draining"
+	KeyTitlePrefix       = "title."
+	KeyValidationPrefix  = "validation."
+	KeyValidationDefault = "validation.default"
+)
+
+// catalogs contiene los mensajes de cada idioma. Los errores se indexan por su
+// código estable, los títulos por el tipo de error y las reglas de validación por su etiqueta.
+var catalogs = map[string]map[string]string{
+	"es": {
+		// Errores de dominio
+		"USER_NOT_FOUND":        "Usuario no encontrado",
+		"EMAIL_TAKEN":           "El email ya está registrado",
+		"FORBIDDEN":             "No tienes permisos para realizar esta acción",
+		"INVALID_QUERY":         "Parámetros de consulta inválidos",
+		"INVALID_CURSOR":        "El cursor de paginación no es válido",
+		"INVALID_CREDENTIALS":   "Credenciales inválidas",
+		"INVALID_REFRESH_TOKEN": "Refresh token inválido",
+		"REFRESH_TOKEN_REUSED":  "El refresh token ya fue utilizado; la sesión se ha cerrado",
+		// Errores HTTP
+		"MISSING_TOKEN":        "No se proporcionó token de autenticación",
+		"INVALID_TOKEN_FORMAT": "Formato de token inválido",
+		"INVALID_TOKEN":        "Token inválido",
+		"TOKEN_REVOKED":        "Token revocado",
+		"RATE_LIMITED":         "Has excedido el límite de peticiones. Por favor, espera un momento.",
+		"INVALID_ID":           "ID inválido",
+		"MALFORMED_BODY":       "El cuerpo de la petición no es un JSON válido",
+		"VALIDATION_FAILED":    "Los datos enviados no son válidos",
+		"INTERNAL_ERROR":       "Error interno del servidor",
+		// Títulos por tipo de error
+		"title.not_found":    "Recurso no encontrado",
+		"title.conflict":     "Conflicto con el estado actual",
+		"title.validation":   "Petición inválida",
+		"title.unauthorized": "No autenticado",
+		"title.forbidden":    "Acceso denegado",
+		"title.rate_limited": "Demasiadas peticiones",
+		"title.internal":     "Error interno",
+		// Reglas de validación
+		"validation.required": "es obligatorio",
+		"validation.email":    "debe ser un email válido",
+		"validation.min":      "debe tener al menos %s caracteres",
+		"validation.max":      "no puede superar los %s caracteres",
+		"validation.default":  "no es válido",
+		// Salud
+		"healthy":  "¡Healthy!",
+		"draining": "La instancia se está apagando",
+	},
+	"en": {
+		"USER_NOT_FOUND":        "User not found",
+		"EMAIL_TAKEN":           "The email is already registered",
+		"FORBIDDEN":             "You are not allowed to perform this action",
+		"INVALID_QUERY":         "Invalid query parameters",
+		"INVALID_CURSOR":        "The pagination cursor is not valid",
+		"INVALID_CREDENTIALS":   "Invalid credentials",
+		"INVALID_REFRESH_TOKEN": "Invalid refresh token",
+		"REFRESH_TOKEN_REUSED":  "The refresh token was already used; the session has been closed",
+		"MISSING_TOKEN":         "No authentication token was provided",
+		"INVALID_TOKEN_FORMAT":  "Invalid token format",
+		"INVALID_TOKEN":         "Invalid token",
+		"TOKEN_REVOKED":         "Token revoked",
+		"RATE_LIMITED":          "You have exceeded the request limit. Please wait a moment.",
+		"INVALID_ID":            "Invalid ID",
+		"MALFORMED_BODY":        "The request body is not valid JSON",
+		"VALIDATION_FAILED":     "The submitted data is not valid",
+		"INTERNAL_ERROR":        "Internal server error",
+		"title.not_found":       "Resource not found",
+		"title.conflict":        "Conflict with the current state",
+		"title.validation":      "Invalid request",
+		"title.unauthorized":    "Unauthenticated",
+		"title.forbidden":       "Access denied",
+		"title.rate_limited":    "Too many requests",
+		"title.internal":        "Internal error",
+		"validation.required":   "is required",
+		"validation.email":      "must be a valid email",
+		"validation.min":        "must be at least %s characters long",
+		"validation.max":        "must be at most %s characters long",
+		"validation.default":    "is not valid",
+		"healthy":               "Healthy!",
+		"draining":              "The instance is shutting down",
+	},
+}
+
+// SupportedLocales devuelve los idiomas con catálogo, ordenados alfabéticamente
+func SupportedLocales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
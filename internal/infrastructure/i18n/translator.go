@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// LocaleKey es la clave del contexto de Gin donde se guarda el idioma negociado
+const LocaleKey = "locale"
+
+// DefaultLocale es el idioma que se usa cuando no se configura otro
+const DefaultLocale = "es"
+
+// Translator resuelve los mensajes del catálogo en el idioma negociado con el cliente
+type Translator struct {
+	defaultLocale string
+	locales       []string
+	matcher       language.Matcher
+}
+
+// NewTranslator crea un traductor cuyo idioma por defecto es defaultLocale.
+// Un valor vacío usa DefaultLocale; un idioma sin catálogo es un error.
+func NewTranslator(defaultLocale string) (*Translator, error) {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	if _, ok := catalogs[defaultLocale]; !ok {
+		return nil, fmt.Errorf("idioma por defecto no soportado: %s", defaultLocale)
+	}
+
+	// El idioma por defecto va primero para que el matcher lo use cuando no hay coincidencias
+	locales := []string{defaultLocale}
+	for _, locale := range SupportedLocales() {
+		if locale != defaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		tags = append(tags, language.MustParse(locale))
+	}
+
+	return &Translator{
+		defaultLocale: defaultLocale,
+		locales:       locales,
+		matcher:       language.NewMatcher(tags),
+	}, nil
+}
+
+// DefaultLocale devuelve el idioma que se usa cuando el cliente no pide uno soportado
+func (t *Translator) DefaultLocale() string {
+	return t.defaultLocale
+}
+
+// Negotiate elige el idioma soportado que mejor encaja con la cabecera Accept-Language
+func (t *Translator) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return t.defaultLocale
+	}
+	_, index, confidence := t.matcher.Match(tags...)
+	if confidence == language.No {
+		return t.defaultLocale
+	}
+	return t.locales[index]
+}
+
+// Translate busca el mensaje en el idioma indicado y, si falta, en el idioma por defecto.
+// Los argumentos se aplican con fmt.Sprintf.
+func (t *Translator) Translate(locale, key string, args ...interface{}) (string, bool) {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[t.defaultLocale][key]
+	}
+	if !ok {
+		return "", false
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return message, true
+}
+
+// Message es como Translate pero devuelve la propia clave si no existe en el catálogo
+func (t *Translator) Message(locale, key string, args ...interface{}) string {
+	if message, ok := t.Translate(locale, key, args...); ok {
+		return message
+	}
+	return key
+}
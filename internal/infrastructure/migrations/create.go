@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ModuleDir devuelve el directorio de las migraciones de un módulo, relativo a la raíz del repositorio
+func ModuleDir(module string) string {
+	return path.Join("internal/modules", module, "infrastructure/persistence/migrations")
+}
+
+// versionLayout genera versiones ordenables a partir de la fecha de creación
+const versionLayout = "20060102150405"
+
+// ErrInvalidName se informa cuando el nombre de una migración no contiene letras ni dígitos
+var ErrInvalidName = errors.New("el nombre de la migración no es válido")
+
+var nameSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create escribe los ficheros up y down vacíos de una nueva migración en dir y devuelve sus rutas
+func Create(dir, name string, now time.Time) (string, string, error) {
+	name = strings.Trim(nameSeparators.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", ErrInvalidName
+	}
+
+	base := fmt.Sprintf("%s_%s", Version(now), name)
+	upPath := filepath.Join(dir, base+".up.sql")
+	downPath := filepath.Join(dir, base+".down.sql")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+	if err := writeNew(upPath, fmt.Sprintf("-- %s: cambios a aplicar\n", name)); err != nil {
+		return "", "", err
+	}
+	if err := writeNew(downPath, fmt.Sprintf("-- %s: deshace los cambios del script up\n", name)); err != nil {
+		return "", "", errors.Join(err, os.Remove(upPath))
+	}
+	return upPath, downPath, nil
+}
+
+// Version devuelve la versión de una migración creada en el instante indicado
+func Version(now time.Time) string {
+	return now.UTC().Format(versionLayout)
+}
+
+// writeNew crea el fichero sin sobrescribir uno existente
+func writeNew(path, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(content)
+	return errors.Join(err, file.Close())
+}
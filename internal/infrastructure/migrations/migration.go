@@ -0,0 +1,153 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Motores de base de datos soportados por las migraciones
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// fileNamePattern reconoce los ficheros <versión>_<nombre>[.<motor>].up.sql y su pareja down.
+// Sin motor, el script es el de Postgres o uno portable; con motor, lo sustituye en ese motor.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)(?:\.(postgres|mysql|sqlite))?\.(up|down)\.sql$`)
+
+var (
+	// ErrDuplicateVersion se informa cuando dos migraciones comparten versión
+	ErrDuplicateVersion = errors.New("versión de migración duplicada")
+	// ErrIncompleteMigration se informa cuando una migración no tiene fichero up o down
+	ErrIncompleteMigration = errors.New("la migración debe tener fichero up y down")
+)
+
+// Migration es una migración versionada con su script de subida y de bajada
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+	// Checksum es el SHA-256 del script up; permite detectar migraciones modificadas tras aplicarse
+	Checksum string
+}
+
+// Load lee las migraciones de la raíz de fsys y las devuelve ordenadas por versión.
+// Solo usa los ficheros sin motor; los que no siguen el formato de nombre se ignoran.
+func Load(fsys fs.FS) ([]Migration, error) {
+	return LoadFor("", fsys)
+}
+
+// LoadFor lee las migraciones de fsys para el motor indicado: en cada versión y sentido
+// prefiere el fichero de ese motor y, si no existe, usa el fichero sin motor
+func LoadFor(driver string, fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	specific := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		fileDriver, direction := match[3], match[4]
+		if fileDriver != "" && fileDriver != driver {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("versión inválida en %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: %d (%s y %s)", ErrDuplicateVersion, version, migration.Name, match[2])
+		}
+
+		// El fichero del motor gana al genérico sin importar el orden de lectura
+		key := fmt.Sprintf("%d.%s", version, direction)
+		if specific[key] && fileDriver == "" {
+			continue
+		}
+		specific[key] = fileDriver != ""
+
+		switch direction {
+		case "up":
+			migration.Up = string(content)
+		case "down":
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s", ErrIncompleteMigration, migration.Version, migration.Name)
+		}
+		migration.Checksum = checksum(migration.Up)
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// LoadAll reúne las migraciones de varios orígenes, normalmente uno por módulo, en una
+// única secuencia ordenada. Las versiones deben ser únicas entre todos los orígenes.
+func LoadAll(sources ...fs.FS) ([]Migration, error) {
+	return LoadAllFor("", sources...)
+}
+
+// LoadAllFor es LoadAll con los scripts del motor indicado
+func LoadAllFor(driver string, sources ...fs.FS) ([]Migration, error) {
+	var all []Migration
+	seen := make(map[int64]string)
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		list, err := LoadFor(driver, source)
+		if err != nil {
+			return nil, err
+		}
+		for _, migration := range list {
+			if name, ok := seen[migration.Version]; ok {
+				return nil, fmt.Errorf("%w: %d (%s y %s)", ErrDuplicateVersion, migration.Version, name, migration.Name)
+			}
+			seen[migration.Version] = migration.Name
+		}
+		all = append(all, list...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Version < all[j].Version
+	})
+	return all, nil
+}
+
+func checksum(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,167 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrChecksumMismatch se informa cuando una migración aplicada se modificó después
+	ErrChecksumMismatch = errors.New("la migración aplicada fue modificada")
+	// ErrMissingMigration se informa al revertir una migración aplicada que no existe en el binario
+	ErrMissingMigration = errors.New("la migración aplicada no existe en el binario")
+	// ErrInvalidSteps se informa cuando se pide revertir un número de pasos no positivo
+	ErrInvalidSteps = errors.New("el número de migraciones a revertir debe ser mayor que cero")
+)
+
+// MigrationStatus describe el estado de una migración conocida o aplicada
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified indica que el script cambió después de aplicarse
+	Modified bool
+	// Missing indica que la migración está aplicada pero no existe en el binario
+	Missing bool
+}
+
+// Migrator aplica y revierte migraciones sobre un Store
+type Migrator struct {
+	store      Store
+	migrations []Migration
+}
+
+func NewMigrator(store Store, migrations []Migration) *Migrator {
+	return &Migrator{store: store, migrations: migrations}
+}
+
+// Up aplica en orden las migraciones pendientes y devuelve las aplicadas.
+// Falla sin aplicar nada si alguna migración ya aplicada fue modificada.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(applied map[int64]AppliedMigration) error {
+		for _, migration := range m.migrations {
+			record, ok := applied[migration.Version]
+			if ok && record.Checksum != migration.Checksum {
+				return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.store.Apply(ctx, migration); err != nil {
+				return fmt.Errorf("aplicando %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas y devuelve las revertidas
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	var done []Migration
+	err := m.locked(ctx, func(applied map[int64]AppliedMigration) error {
+		versions := sortedVersions(applied)
+		for i := len(versions) - 1; i >= 0 && len(done) < steps; i-- {
+			record := applied[versions[i]]
+			migration, ok := known[record.Version]
+			if !ok {
+				return fmt.Errorf("%w: %d_%s", ErrMissingMigration, record.Version, record.Name)
+			}
+			if err := m.store.Revert(ctx, migration); err != nil {
+				return fmt.Errorf("revirtiendo %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status devuelve el estado de cada migración ordenado por versión
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.locked(ctx, func(applied map[int64]AppliedMigration) error {
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if record, ok := applied[migration.Version]; ok {
+				appliedAt := record.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = record.Checksum != migration.Checksum
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		// Lo que queda aplicado no corresponde a ninguna migración del binario
+		for _, version := range sortedVersions(applied) {
+			record := applied[version]
+			appliedAt := record.AppliedAt
+			statuses = append(statuses, MigrationStatus{
+				Version:   record.Version,
+				Name:      record.Name,
+				Applied:   true,
+				AppliedAt: &appliedAt,
+				Missing:   true,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// locked ejecuta fn con el bloqueo tomado y las migraciones aplicadas indexadas por versión
+func (m *Migrator) locked(ctx context.Context, fn func(applied map[int64]AppliedMigration) error) (err error) {
+	if err := m.store.Lock(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, m.store.Unlock(context.WithoutCancel(ctx)))
+	}()
+
+	if err := m.store.EnsureTable(ctx); err != nil {
+		return fmt.Errorf("creando la tabla schema_migrations: %w", err)
+	}
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[int64]AppliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return fn(applied)
+}
+
+func sortedVersions(applied map[int64]AppliedMigration) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
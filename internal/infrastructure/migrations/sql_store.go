@@ -0,0 +1,205 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+const (
+	// advisoryLockKey identifica el bloqueo de migraciones entre todas las réplicas en Postgres
+	advisoryLockKey int64 = 7_318_204_551
+	// namedLockName es el equivalente en MySQL, que nombra sus bloqueos con cadenas
+	namedLockName = "schema_migrations"
+)
+
+var (
+	errNotLocked = errors.New("el almacén de migraciones no está bloqueado")
+	// ErrUnsupportedDriver se informa cuando no hay dialecto para el motor indicado
+	ErrUnsupportedDriver = errors.New("motor de base de datos no soportado")
+)
+
+// dialect reúne lo que cambia entre motores: el bloqueo entre réplicas y el SQL de
+// schema_migrations
+type dialect struct {
+	lock        func(ctx context.Context, conn *sql.Conn) error
+	unlock      func(ctx context.Context, conn *sql.Conn) error
+	createTable string
+	insert      string
+	delete      string
+}
+
+var dialects = map[string]dialect{
+	DriverPostgres: {
+		lock: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey)
+			return err
+		},
+		unlock: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+			return err
+		},
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			checksum   TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`,
+		insert: "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+		delete: "DELETE FROM schema_migrations WHERE version = $1",
+	},
+	DriverMySQL: {
+		lock: func(ctx context.Context, conn *sql.Conn) error {
+			var acquired sql.NullInt64
+			if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", namedLockName).Scan(&acquired); err != nil {
+				return err
+			}
+			if acquired.Int64 != 1 {
+				return errors.New("GET_LOCK no concedió el bloqueo")
+			}
+			return nil
+		},
+		unlock: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", namedLockName)
+			return err
+		},
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			checksum   CHAR(64) NOT NULL,
+			applied_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+		)`,
+		insert: "INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)",
+		delete: "DELETE FROM schema_migrations WHERE version = ?",
+	},
+	// SQLite no tiene bloqueos con nombre: la base de datos es un fichero local de una
+	// sola instancia y el propio motor serializa las escrituras
+	DriverSQLite: {
+		lock:   func(context.Context, *sql.Conn) error { return nil },
+		unlock: func(context.Context, *sql.Conn) error { return nil },
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			checksum   TEXT NOT NULL,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		insert: "INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)",
+		delete: "DELETE FROM schema_migrations WHERE version = ?",
+	},
+}
+
+// SQLStore guarda el estado en schema_migrations y serializa las migraciones con el
+// bloqueo del motor. El bloqueo pertenece a la sesión, por eso todas las operaciones
+// usan la misma conexión mientras está tomado.
+//
+// En MySQL el DDL confirma la transacción implícitamente: una migración que falla a
+// mitad puede dejar aplicadas sus primeras sentencias.
+type SQLStore struct {
+	db      *sql.DB
+	dialect dialect
+	conn    *sql.Conn
+}
+
+// NewSQLStore crea el almacén para el motor indicado (postgres, mysql o sqlite)
+func NewSQLStore(db *sql.DB, driver string) (*SQLStore, error) {
+	dialect, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDriver, driver)
+	}
+	return &SQLStore{db: db, dialect: dialect}, nil
+}
+
+// NewSQLMigrator crea un migrador con los scripts del motor indicado de cada origen
+func NewSQLMigrator(db *sql.DB, driver string, sources ...fs.FS) (*Migrator, error) {
+	store, err := NewSQLStore(db, driver)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := LoadAllFor(driver, sources...)
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrator(store, migrations), nil
+}
+
+func (s *SQLStore) Lock(ctx context.Context) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.dialect.lock(ctx, conn); err != nil {
+		conn.Close()
+		return fmt.Errorf("obteniendo el bloqueo de migraciones: %w", err)
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *SQLStore) Unlock(ctx context.Context) error {
+	if s.conn == nil {
+		return errNotLocked
+	}
+	conn := s.conn
+	s.conn = nil
+
+	err := s.dialect.unlock(ctx, conn)
+	return errors.Join(err, conn.Close())
+}
+
+func (s *SQLStore) EnsureTable(ctx context.Context) error {
+	if s.conn == nil {
+		return errNotLocked
+	}
+	_, err := s.conn.ExecContext(ctx, s.dialect.createTable)
+	return err
+}
+
+func (s *SQLStore) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	if s.conn == nil {
+		return nil, errNotLocked
+	}
+	rows, err := s.conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []AppliedMigration
+	for rows.Next() {
+		var migration AppliedMigration
+		if err := rows.Scan(&migration.Version, &migration.Name, &migration.Checksum, &migration.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, migration)
+	}
+	return applied, rows.Err()
+}
+
+func (s *SQLStore) Apply(ctx context.Context, migration Migration) error {
+	return s.inTx(ctx, migration.Up, s.dialect.insert, migration.Version, migration.Name, migration.Checksum)
+}
+
+func (s *SQLStore) Revert(ctx context.Context, migration Migration) error {
+	return s.inTx(ctx, migration.Down, s.dialect.delete, migration.Version)
+}
+
+// inTx ejecuta el script y la actualización de schema_migrations en una transacción,
+// de modo que una migración fallida no queda registrada a medias
+func (s *SQLStore) inTx(ctx context.Context, script, record string, args ...interface{}) error {
+	if s.conn == nil {
+		return errNotLocked
+	}
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
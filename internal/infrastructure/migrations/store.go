@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"time"
+)
+
+// AppliedMigration es el registro de una migración en la tabla schema_migrations
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Store persiste el estado de las migraciones y ejecuta sus scripts
+type Store interface {
+	// Lock obtiene un bloqueo exclusivo para que varias réplicas no migren a la vez
+	Lock(ctx context.Context) error
+	Unlock(ctx context.Context) error
+	// EnsureTable crea la tabla de control si no existe
+	EnsureTable(ctx context.Context) error
+	// Applied devuelve las migraciones aplicadas ordenadas por versión
+	Applied(ctx context.Context) ([]AppliedMigration, error)
+	// Apply ejecuta el script up y registra la migración en una misma transacción
+	Apply(ctx context.Context, migration Migration) error
+	// Revert ejecuta el script down y elimina el registro en una misma transacción
+	Revert(ctx context.Context, migration Migration) error
+}
@@ -0,0 +1,53 @@
+package module
+
+import (
+	"context"
+	"io/fs"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/modules/health/domain/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Module es una unidad funcional que se conecta al servidor sin que main.go ni la
+// configuración conozcan sus tipos. Name y Migrations deben funcionar antes de Init,
+// porque el subcomando migrate los usa sin arrancar el servidor.
+type Module interface {
+	// Name identifica al módulo en los logs y en los hooks de apagado; debe ser único
+	Name() string
+	// Migrations devuelve las migraciones SQL del módulo o nil si no tiene tablas
+	Migrations() fs.FS
+	// Init construye los repositorios y handlers del módulo; se llama con el esquema ya migrado
+	Init(ctx context.Context, deps Dependencies) error
+	// RegisterRoutes registra las rutas HTTP del módulo
+	RegisterRoutes(routes Routes)
+	// HealthChecks son las dependencias propias que deben estar disponibles para atender tráfico
+	HealthChecks() []port.Checker
+	// ShutdownHooks liberan los recursos del módulo durante el apagado
+	ShutdownHooks() []ShutdownHook
+}
+
+// Authenticator lo implementa el módulo que valida los tokens de acceso de las rutas protegidas
+type Authenticator interface {
+	Authenticate() gin.HandlerFunc
+}
+
+// Dependencies son los recursos compartidos que reciben los módulos al inicializarse
+type Dependencies struct {
+	Config *config.Config
+}
+
+// Routes agrupa los puntos de montaje de las rutas de un módulo
+type Routes struct {
+	// Public no exige autenticación
+	Public gin.IRouter
+	// Protected cuelga de /api y exige un token de acceso válido
+	Protected gin.IRouter
+}
+
+// ShutdownHook libera un recurso durante el apagado ordenado
+type ShutdownHook struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
@@ -0,0 +1,105 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"go-hexagonal-template/internal/modules/health/domain/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrDuplicateModule se informa cuando dos módulos comparten nombre
+	ErrDuplicateModule = errors.New("módulo duplicado")
+	// ErrAuthenticator se informa cuando no hay exactamente un módulo que autentique las rutas protegidas
+	ErrAuthenticator = errors.New("debe haber exactamente un módulo que proporcione autenticación")
+)
+
+// Registry conserva los módulos de la aplicación en orden de registro
+type Registry struct {
+	modules []Module
+}
+
+// NewRegistry valida que los nombres de los módulos sean únicos
+func NewRegistry(modules ...Module) (*Registry, error) {
+	seen := make(map[string]bool, len(modules))
+	for _, module := range modules {
+		if module.Name() == "" || seen[module.Name()] {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateModule, module.Name())
+		}
+		seen[module.Name()] = true
+	}
+	return &Registry{modules: modules}, nil
+}
+
+// Modules devuelve los módulos registrados
+func (r *Registry) Modules() []Module {
+	return r.modules
+}
+
+// Migrations devuelve los orígenes de migraciones de todos los módulos que tienen tablas
+func (r *Registry) Migrations() []fs.FS {
+	var sources []fs.FS
+	for _, module := range r.modules {
+		if migrations := module.Migrations(); migrations != nil {
+			sources = append(sources, migrations)
+		}
+	}
+	return sources
+}
+
+// Init inicializa los módulos en orden de registro y se detiene en el primer error
+func (r *Registry) Init(ctx context.Context, deps Dependencies) error {
+	for _, module := range r.modules {
+		if err := module.Init(ctx, deps); err != nil {
+			return fmt.Errorf("inicializando el módulo %s: %w", module.Name(), err)
+		}
+	}
+	return nil
+}
+
+// RegisterRoutes monta las rutas de todos los módulos. Las rutas protegidas cuelgan de /api
+// con el middleware del único módulo que implementa Authenticator.
+func (r *Registry) RegisterRoutes(router gin.IRouter) error {
+	var authenticators []Authenticator
+	for _, module := range r.modules {
+		if authenticator, ok := module.(Authenticator); ok {
+			authenticators = append(authenticators, authenticator)
+		}
+	}
+	if len(authenticators) != 1 {
+		return fmt.Errorf("%w: hay %d", ErrAuthenticator, len(authenticators))
+	}
+
+	routes := Routes{
+		Public:    router,
+		Protected: router.Group("/api", authenticators[0].Authenticate()),
+	}
+	for _, module := range r.modules {
+		module.RegisterRoutes(routes)
+	}
+	return nil
+}
+
+// HealthChecks reúne los chequeos de readiness de todos los módulos
+func (r *Registry) HealthChecks() []port.Checker {
+	var checks []port.Checker
+	for _, module := range r.modules {
+		checks = append(checks, module.HealthChecks()...)
+	}
+	return checks
+}
+
+// ShutdownHooks reúne los hooks de apagado de todos los módulos con el nombre del módulo como prefijo
+func (r *Registry) ShutdownHooks() []ShutdownHook {
+	var hooks []ShutdownHook
+	for _, module := range r.modules {
+		for _, hook := range module.ShutdownHooks() {
+			hooks = append(hooks, ShutdownHook{Name: module.Name() + "/" + hook.Name, Fn: hook.Fn})
+		}
+	}
+	return hooks
+}
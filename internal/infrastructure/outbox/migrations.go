@@ -0,0 +1,19 @@
+package outbox
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations devuelve las migraciones SQL de la tabla del outbox incluidas en el binario
+func Migrations() fs.FS {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		// El directorio está fijado por la directiva embed: no puede fallar
+		panic(err)
+	}
+	return sub
+}
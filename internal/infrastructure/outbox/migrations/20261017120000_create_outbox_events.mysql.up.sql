@@ -0,0 +1,14 @@
+-- Eventos pendientes de publicar, versión para MySQL. MySQL no admite índices parciales,
+-- así que el índice incluye published_at para descartar los ya publicados.
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    payload         LONGTEXT NOT NULL,
+    occurred_at     DATETIME(6) NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NOT NULL,
+    last_error      TEXT NOT NULL,
+    published_at    DATETIME(6),
+    INDEX idx_outbox_events_pending (published_at, next_attempt_at)
+);
@@ -0,0 +1,13 @@
+-- Eventos pendientes de publicar, versión para SQLite
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    name            TEXT NOT NULL,
+    payload         TEXT NOT NULL,
+    occurred_at     DATETIME NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    published_at    DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at) WHERE published_at IS NULL;
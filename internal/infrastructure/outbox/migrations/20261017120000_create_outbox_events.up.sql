@@ -0,0 +1,14 @@
+-- Eventos pendientes de publicar. El relay los lee por orden de ID y los marca como
+-- publicados; los que fallan se reintentan a partir de next_attempt_at.
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    name            TEXT NOT NULL,
+    payload         TEXT NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    published_at    TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at) WHERE published_at IS NULL;
@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"io/fs"
+	"os"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/events"
+	"go-hexagonal-template/internal/infrastructure/module"
+	healthport "go-hexagonal-template/internal/modules/health/domain/port"
+	"go-hexagonal-template/internal/modules/shared/domain/port"
+)
+
+// Module crea la tabla del outbox y publica sus eventos con el relay mientras el servidor
+// está en marcha. Con EVENTS_PUBLISHER=bus los eventos llegan a los suscriptores de bus.
+type Module struct {
+	bus    *events.Bus
+	file   *os.File
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewModule(bus *events.Bus) *Module {
+	return &Module{
+		bus: bus,
+	}
+}
+
+func (m *Module) Name() string {
+	return "outbox"
+}
+
+func (m *Module) Migrations() fs.FS {
+	return Migrations()
+}
+
+// Init arranca el relay en segundo plano; se detiene con el hook de apagado
+func (m *Module) Init(ctx context.Context, deps module.Dependencies) error {
+	cfg := deps.Config
+	publisher, err := m.newPublisher(cfg.Events)
+	if err != nil {
+		return err
+	}
+
+	relay := NewRelay(cfg.DB, publisher, cfg.Events.PollInterval, cfg.Events.BatchSize, cfg.Events.ClaimTimeout)
+	runCtx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go func() {
+		defer close(m.done)
+		relay.Run(runCtx)
+	}()
+	return nil
+}
+
+func (m *Module) newPublisher(cfg *config.EventsConfig) (port.EventPublisher, error) {
+	switch cfg.Publisher {
+	case config.EventPublisherWebhook:
+		return events.NewWebhookPublisher(cfg.WebhookURL, cfg.WebhookTimeout), nil
+	case config.EventPublisherStdout:
+		return events.NewWriterPublisher(os.Stdout), nil
+	case config.EventPublisherFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		m.file = file
+		return events.NewWriterPublisher(file), nil
+	default:
+		return m.bus, nil
+	}
+}
+
+func (m *Module) RegisterRoutes(routes module.Routes) {}
+
+func (m *Module) HealthChecks() []healthport.Checker {
+	return nil
+}
+
+func (m *Module) ShutdownHooks() []module.ShutdownHook {
+	return []module.ShutdownHook{{Name: "relay", Fn: m.stop}}
+}
+
+// stop espera a que el relay termine el evento en curso; los eventos reservados que no
+// llegó a publicar se publican de nuevo cuando vence su reserva
+func (m *Module) stop(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+	select {
+	case <-m.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if m.file != nil {
+		return m.file.Close()
+	}
+	return nil
+}
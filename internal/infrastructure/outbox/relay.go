@@ -0,0 +1,141 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go-hexagonal-template/internal/modules/shared/domain/port"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	retryBaseDelay = time.Second
+	maxRetryDelay  = 5 * time.Minute
+)
+
+// Relay publica los eventos pendientes del outbox. La entrega es al menos una vez: un
+// evento se marca como publicado después de entregarlo, así que un fallo entre ambos
+// pasos hace que se entregue de nuevo. Los eventos que fallan no bloquean a los siguientes.
+type Relay struct {
+	db           *gorm.DB
+	publisher    port.EventPublisher
+	pollInterval time.Duration
+	batchSize    int
+	claimTimeout time.Duration
+}
+
+// NewRelay crea el relay. claimTimeout es el tiempo que un lote queda reservado para esta
+// instancia; si no lo publica en ese plazo, otra puede volver a reservar sus eventos.
+func NewRelay(db *gorm.DB, publisher port.EventPublisher, pollInterval time.Duration, batchSize int, claimTimeout time.Duration) *Relay {
+	return &Relay{
+		db:           db,
+		publisher:    publisher,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		claimTimeout: claimTimeout,
+	}
+}
+
+// Run publica lotes hasta que ctx termina. Tras un lote completo vuelve a leer sin
+// esperar; si quedan menos eventos que el tamaño del lote espera pollInterval.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		processed, err := r.ProcessBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Error publicando eventos del outbox: %v", err)
+		}
+		if err == nil && processed == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// ProcessBatch intenta publicar un lote de eventos pendientes y retorna cuántos procesó.
+// Los que fallan se reprograman con una espera exponencial.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	records, leaseEnd, err := r.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Se publica fuera de la transacción: una llamada lenta al destino no retiene una
+	// conexión del pool ni bloqueos, y cada evento entregado se marca de inmediato
+	processed := 0
+	for _, record := range records {
+		// Pasado el plazo de la reserva otra instancia puede haber tomado el resto del lote
+		if time.Now().After(leaseEnd) {
+			break
+		}
+
+		updates := map[string]interface{}{"published_at": time.Now()}
+		if err := r.publisher.Publish(ctx, record.Message()); err != nil {
+			attempts := record.Attempts + 1
+			updates = map[string]interface{}{
+				"attempts":        attempts,
+				"last_error":      err.Error(),
+				"next_attempt_at": time.Now().Add(retryDelay(attempts)),
+			}
+		}
+		err := r.db.WithContext(ctx).Model(&Record{}).
+			Where("id = ? AND published_at IS NULL", record.ID).
+			Updates(updates).Error
+		if err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// claim reserva un lote de eventos pendientes moviendo su next_attempt_at al final de la
+// reserva, en una transacción corta. SKIP LOCKED reparte los eventos entre réplicas sin
+// que dos reserven el mismo; si el relay se detiene a mitad de lote, los eventos no
+// publicados vuelven a estar pendientes cuando vence la reserva.
+func (r *Relay) claim(ctx context.Context) ([]Record, time.Time, error) {
+	var records []Record
+	var leaseEnd time.Time
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		leaseEnd = now.Add(r.claimTimeout)
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND next_attempt_at <= ?", now).
+			Order("id ASC").
+			Limit(r.batchSize).
+			Find(&records).Error
+		if err != nil || len(records) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+		return tx.Model(&Record{}).Where("id IN ?", ids).Update("next_attempt_at", leaseEnd).Error
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return records, leaseEnd, nil
+}
+
+// retryDelay duplica la espera en cada intento fallido hasta maxRetryDelay
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,77 @@
+// Package outbox guarda los eventos del dominio en la misma transacción que las
+// escrituras que los originan y los publica después desde un relay en segundo plano.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/shared/domain/port"
+
+	"gorm.io/gorm"
+)
+
+// Record es una fila de la tabla outbox_events
+type Record struct {
+	ID            uint `gorm:"primaryKey"`
+	Name          string
+	Payload       string
+	OccurredAt    time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	PublishedAt   *time.Time
+}
+
+func (Record) TableName() string {
+	return "outbox_events"
+}
+
+// Message convierte la fila en el mensaje que reciben los publicadores
+func (r Record) Message() model.EventMessage {
+	return model.EventMessage{
+		ID:         r.ID,
+		Name:       r.Name,
+		Payload:    json.RawMessage(r.Payload),
+		OccurredAt: r.OccurredAt,
+	}
+}
+
+// GormStore implementa el puerto EventOutbox sobre la tabla outbox_events
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore crea el outbox. Add usa la transacción en curso en el contexto, si la hay.
+func NewGormStore(db *gorm.DB) port.EventOutbox {
+	return &GormStore{
+		db: db,
+	}
+}
+
+// Add implementa el método Add de la interfaz EventOutbox
+func (s *GormStore) Add(ctx context.Context, events ...model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	records := make([]Record, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("serializando el evento %s: %w", event.EventName(), err)
+		}
+		records = append(records, Record{
+			Name:          event.EventName(),
+			Payload:       string(payload),
+			OccurredAt:    now,
+			NextAttemptAt: now,
+		})
+	}
+	return transaction.DB(ctx, s.db).Create(&records).Error
+}
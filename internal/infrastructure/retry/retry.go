@@ -0,0 +1,67 @@
+// Package retry reintenta operaciones que fallan de forma transitoria, como conectar con
+// una dependencia que aún está arrancando
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy define cuánto se espera entre intentos y durante cuánto tiempo se reintenta
+type Policy struct {
+	// InitialBackoff es la espera tras el primer fallo; se duplica en cada intento
+	InitialBackoff time.Duration
+	// MaxBackoff limita la espera entre dos intentos
+	MaxBackoff time.Duration
+	// MaxWait limita el tiempo total desde el primer intento; 0 hace un único intento
+	MaxWait time.Duration
+}
+
+// Backoff retorna la espera tras el fallo número attempt. Se duplica hasta MaxBackoff y se
+// elige al azar entre la mitad y el total, para que varias instancias no reintenten a la vez.
+func (p Policy) Backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// Do ejecuta fn hasta que tenga éxito, se agote MaxWait o se cancele ctx. Antes de cada
+// espera llama a onRetry, si no es nil, con el intento fallido, su error y la espera.
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error, onRetry func(attempt int, err error, wait time.Duration)) error {
+	deadline := time.Now().Add(policy.MaxWait)
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%d intentos fallidos: %w", attempt, err)
+		}
+		wait := min(policy.Backoff(attempt), remaining)
+		if onRetry != nil {
+			onRetry(attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
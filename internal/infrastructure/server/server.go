@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Options define los plazos del servidor HTTP y del apagado
+type Options struct {
+	Addr         string
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// DrainPeriod es el tiempo que la instancia sigue atendiendo mientras informa que no está lista,
+	// para que el balanceador deje de enviarle tráfico antes de cerrar las conexiones
+	DrainPeriod time.Duration
+	// ShutdownTimeout es el plazo máximo del apagado completo, contado desde la señal
+	ShutdownTimeout time.Duration
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Server gestiona el ciclo de vida del servidor HTTP: arranque, drenaje y apagado ordenado
+type Server struct {
+	httpServer *http.Server
+	options    Options
+
+	mu            sync.Mutex
+	drainHooks    []func()
+	shutdownHooks []shutdownHook
+	// background cuenta las tareas lanzadas con Go que el apagado debe esperar
+	background sync.WaitGroup
+}
+
+// New crea un servidor para el handler indicado
+func New(handler http.Handler, options Options) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:         options.Addr,
+			Handler:      handler,
+			ReadTimeout:  options.ReadTimeout,
+			WriteTimeout: options.WriteTimeout,
+			IdleTimeout:  options.IdleTimeout,
+		},
+		options: options,
+	}
+}
+
+// OnDrain registra una función que se ejecuta al comenzar el drenaje
+func (s *Server) OnDrain(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drainHooks = append(s.drainHooks, fn)
+}
+
+// OnShutdown registra un hook de apagado. Los hooks se ejecutan después de cerrar el servidor
+// HTTP y en orden inverso al de registro, de modo que lo que se abrió primero se cierra al final.
+func (s *Server) OnShutdown(name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shutdownHooks = append(s.shutdownHooks, shutdownHook{name: name, fn: fn})
+}
+
+// Go ejecuta fn en segundo plano, típicamente el arranque de la aplicación. El apagado espera
+// a que fn termine antes de ejecutar los hooks para no perder los que registre mientras tanto;
+// fn debe abandonar su trabajo cuando se cancele el contexto que recibe Serve.
+func (s *Server) Go(fn func()) {
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		fn()
+	}()
+}
+
+// Run escucha en la dirección configurada y bloquea hasta que ctx se cancela y termina el apagado
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.options.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve atiende peticiones en listener hasta que ctx se cancela y luego apaga el servidor
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	return s.shutdown()
+}
+
+// shutdown drena la instancia, cierra el servidor HTTP esperando a las peticiones en curso
+// y ejecuta los hooks. Si se supera ShutdownTimeout las conexiones se cierran a la fuerza.
+func (s *Server) shutdown() error {
+	deadline := time.Now().Add(s.options.ShutdownTimeout)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	s.mu.Lock()
+	drainHooks := append([]func(){}, s.drainHooks...)
+	s.mu.Unlock()
+
+	log.Printf("Apagando el servidor: drenando durante %s", s.options.DrainPeriod)
+	for _, fn := range drainHooks {
+		fn()
+	}
+	select {
+	case <-time.After(s.options.DrainPeriod):
+	case <-ctx.Done():
+	}
+
+	var errs []error
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("cerrando el servidor HTTP: %w", err))
+		// Se alcanzó el plazo máximo: cortar las conexiones que siguen abiertas
+		if closeErr := s.httpServer.Close(); closeErr != nil {
+			errs = append(errs, closeErr)
+		}
+	}
+
+	// Las tareas de Go pueden seguir registrando hooks hasta que terminan
+	background := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(background)
+	}()
+	select {
+	case <-background:
+	case <-ctx.Done():
+		log.Printf("ADVERTENCIA: el arranque no terminó antes del plazo de apagado; sus hooks pueden no ejecutarse")
+	}
+
+	s.mu.Lock()
+	shutdownHooks := append([]shutdownHook{}, s.shutdownHooks...)
+	s.mu.Unlock()
+
+	for i := len(shutdownHooks) - 1; i >= 0; i-- {
+		hook := shutdownHooks[i]
+		if err := hook.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("hook de apagado %s: %w", hook.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
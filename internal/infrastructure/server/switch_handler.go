@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// SwitchHandler delega en un handler que puede sustituirse mientras el servidor atiende
+// peticiones, por ejemplo para servir solo las sondas hasta que la aplicación termina de arrancar
+type SwitchHandler struct {
+	current atomic.Pointer[http.Handler]
+}
+
+// NewSwitchHandler crea un SwitchHandler que empieza atendiendo con initial
+func NewSwitchHandler(initial http.Handler) *SwitchHandler {
+	h := &SwitchHandler{}
+	h.Switch(initial)
+	return h
+}
+
+// Switch hace que las siguientes peticiones se atiendan con handler; las que están en curso terminan con el anterior
+func (h *SwitchHandler) Switch(handler http.Handler) {
+	h.current.Store(&handler)
+}
+
+func (h *SwitchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*h.current.Load()).ServeHTTP(w, r)
+}
@@ -0,0 +1,112 @@
+// Package transaction implementa el puerto TxManager con GORM. La transacción viaja en el
+// contexto, así que los repositorios solo necesitan obtener su conexión con DB.
+package transaction
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-hexagonal-template/internal/modules/shared/domain/port"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	// DefaultMaxAttempts es el número de intentos ante fallos de serialización
+	DefaultMaxAttempts = 3
+	retryBackoff       = 20 * time.Millisecond
+
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	// mysqlDeadlock es ER_LOCK_DEADLOCK: MySQL revierte la transacción completa
+	mysqlDeadlock = 1213
+)
+
+// ReadReplicas es el nombre con el que se registra el resolver de réplicas de lectura
+const ReadReplicas = "read_replicas"
+
+// txKey identifica la transacción en curso dentro del contexto
+type txKey struct{}
+
+// GormTxManager implementa port.TxManager sobre GORM
+type GormTxManager struct {
+	db          *gorm.DB
+	maxAttempts int
+}
+
+// NewGormTxManager crea un TxManager que reintenta la transacción completa hasta
+// maxAttempts veces si la base de datos la aborta por serialización o interbloqueo
+func NewGormTxManager(db *gorm.DB, maxAttempts int) port.TxManager {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &GormTxManager{
+		db:          db,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// WithinTx implementa el método WithinTx de la interfaz TxManager
+func (m *GormTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		// GORM convierte la transacción anidada en un savepoint. No se reintenta: la
+		// transacción exterior ya está abortada y es ella quien debe repetirse.
+		return tx.WithContext(ctx).Transaction(func(nested *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, nested))
+		})
+	}
+
+	var err error
+	for attempt := 1; attempt <= m.maxAttempts; attempt++ {
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		})
+		if err == nil || !IsRetryable(err) || attempt == m.maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryBackoff * time.Duration(attempt)):
+		}
+	}
+	return err
+}
+
+// DB retorna la transacción en curso en ctx o, si no hay ninguna, db. En ambos casos las
+// consultas quedan ligadas a ctx.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// ReadDB es como DB, pero fuera de una transacción envía las consultas a las réplicas de
+// lectura si hay alguna configurada. Solo debe usarse en lecturas que toleran el retraso de
+// replicación; dentro de una transacción se lee del primario para ver sus propios cambios.
+func ReadDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx).Clauses(dbresolver.Use(ReadReplicas))
+}
+
+// IsRetryable indica si la base de datos abortó la transacción por un conflicto con otra
+// concurrente, en cuyo caso repetirla desde el principio puede tener éxito
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlock
+	}
+	return false
+}
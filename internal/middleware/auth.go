@@ -1,49 +1,76 @@
 package middleware
 
 import (
-	"net/http"
+	"strconv"
 	"strings"
 
 	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// Errores de autenticación que AuthMiddleware registra para ErrorHandler
+var (
+	errMissingToken       = apperror.Unauthorized("MISSING_TOKEN", "No se proporcionó token de autenticación")
+	errInvalidTokenFormat = apperror.Unauthorized("INVALID_TOKEN_FORMAT", "Formato de token inválido")
+	errInvalidToken       = apperror.Unauthorized("INVALID_TOKEN", "Token inválido")
+	errTokenRevoked       = apperror.Unauthorized("TOKEN_REVOKED", "Token revocado")
+)
+
+func AuthMiddleware(tokenManager *auth.TokenManager, revocationStore port.TokenRevocationStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Obtener el token del header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "No se proporcionó token de autenticación",
-			})
-			c.Abort()
+			abortWithError(c, errMissingToken)
 			return
 		}
 
 		// Verificar el formato del token
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Formato de token inválido",
-			})
-			c.Abort()
+			abortWithError(c, errInvalidTokenFormat)
 			return
 		}
 
 		// Validar el token
-		claims, err := auth.ValidateToken(parts[1])
+		claims, err := tokenManager.ValidateToken(parts[1])
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Token inválido",
-			})
-			c.Abort()
+			abortWithError(c, errInvalidToken)
+			return
+		}
+
+		userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+		if err != nil || claims.ID == "" || claims.IssuedAt == nil {
+			abortWithError(c, errInvalidToken)
+			return
+		}
+
+		// Verificar que el token no haya sido revocado
+		revoked, err := revocationStore.IsRevoked(c.Request.Context(), claims.ID, uint(userID), claims.IssuedAt.Time)
+		if err != nil {
+			abortWithError(c, apperror.Wrap(err, apperror.KindInternal, apperror.CodeInternal, "Error al verificar el token"))
+			return
+		}
+		if revoked {
+			abortWithError(c, errTokenRevoked)
 			return
 		}
 
 		// Guardar la información del usuario en el contexto
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("roles", claims.Roles)
+		c.Set("permissions", claims.Permissions)
+		c.Set("claims", claims)
+		c.Set("principal", &sharedmodel.Principal{
+			UserID:      uint(userID),
+			Roles:       claims.Roles,
+			Permissions: claims.Permissions,
+		})
 
 		c.Next()
 	}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission restringe la ruta a los tokens que conceden el permiso indicado.
+// Debe usarse después de AuthMiddleware.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		permissions := c.GetStringSlice("permissions")
+		for _, p := range permissions {
+			if p == permission {
+				c.Next()
+				return
+			}
+		}
+
+		abortWithError(c, sharedmodel.ErrForbidden)
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"go-hexagonal-template/internal/infrastructure/i18n"
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType es el tipo de contenido de las respuestas de error (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// Problem es el cuerpo de las respuestas de error según RFC 7807
+type Problem struct {
+	Type     string                `json:"type" example:"/problems/user-not-found"`
+	Title    string                `json:"title" example:"Recurso no encontrado"`
+	Status   int                   `json:"status" example:"404"`
+	Detail   string                `json:"detail,omitempty" example:"Usuario no encontrado"`
+	Instance string                `json:"instance,omitempty" example:"/api/users/42"`
+	Code     string                `json:"code,omitempty" example:"USER_NOT_FOUND"`
+	Errors   []apperror.FieldError `json:"errors,omitempty"`
+}
+
+// problemStatus asocia cada tipo de error con su código HTTP
+var problemStatus = map[apperror.Kind]int{
+	apperror.KindNotFound:     http.StatusNotFound,
+	apperror.KindConflict:     http.StatusConflict,
+	apperror.KindValidation:   http.StatusBadRequest,
+	apperror.KindUnauthorized: http.StatusUnauthorized,
+	apperror.KindForbidden:    http.StatusForbidden,
+	apperror.KindRateLimited:  http.StatusTooManyRequests,
+	apperror.KindInternal:     http.StatusInternalServerError,
+}
+
+// ErrorHandler traduce el último error registrado con c.Error a una respuesta
+// application/problem+json en el idioma negociado. Debe registrarse antes que el resto de middlewares.
+func ErrorHandler(translator *i18n.Translator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeProblem(c, translator, c.Errors.Last().Err)
+	}
+}
+
+// newProblem construye el cuerpo RFC 7807 para un error. Los errores que no
+// pertenecen al catálogo se tratan como internos y no exponen su mensaje.
+func newProblem(translator *i18n.Translator, locale string, err error, instance string) Problem {
+	appErr, ok := apperror.As(err)
+	if !ok {
+		appErr = apperror.Internal(apperror.CodeInternal, "Error interno del servidor")
+	}
+
+	status, ok := problemStatus[appErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	// El detalle sale del catálogo; si el código no está traducido se usa el mensaje
+	// del error, salvo en los internos, que nunca muestran la causa original
+	detail, ok := translator.Translate(locale, appErr.Code)
+	if !ok {
+		detail = appErr.Message
+		if appErr.Kind != apperror.KindInternal {
+			detail = err.Error()
+		}
+	}
+
+	return Problem{
+		Type:     problemType(appErr.Code),
+		Title:    translator.Message(locale, i18n.KeyTitlePrefix+string(appErr.Kind)),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     appErr.Code,
+		Errors:   translateFieldErrors(translator, locale, appErr.Fields),
+	}
+}
+
+// translateFieldErrors completa el mensaje de cada campo según la regla incumplida
+func translateFieldErrors(translator *i18n.Translator, locale string, fields []apperror.FieldError) []apperror.FieldError {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	translated := make([]apperror.FieldError, 0, len(fields))
+	for _, field := range fields {
+		var args []interface{}
+		if field.Param != "" {
+			args = append(args, field.Param)
+		}
+		message, ok := translator.Translate(locale, i18n.KeyValidationPrefix+field.Code, args...)
+		if !ok {
+			message = translator.Message(locale, i18n.KeyValidationDefault)
+		}
+		field.Message = message
+		translated = append(translated, field)
+	}
+	return translated
+}
+
+// problemType genera una referencia relativa a partir del código del error
+func problemType(code string) string {
+	if code == "" {
+		return "about:blank"
+	}
+	return "/problems/" + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+func writeProblem(c *gin.Context, translator *i18n.Translator, err error) {
+	problem := newProblem(translator, localeOf(c, translator), err, c.Request.URL.Path)
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// abortWithError registra el error para que ErrorHandler lo traduzca y detiene la cadena
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"go-hexagonal-template/internal/infrastructure/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware negocia el idioma de la respuesta a partir de Accept-Language,
+// lo guarda en el contexto y lo anuncia en la cabecera Content-Language
+func LocaleMiddleware(translator *i18n.Translator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := translator.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(i18n.LocaleKey, locale)
+		c.Header("Content-Language", locale)
+		c.Next()
+	}
+}
+
+// localeOf devuelve el idioma guardado por LocaleMiddleware o lo negocia si no se registró
+func localeOf(c *gin.Context, translator *i18n.Translator) string {
+	if locale := c.GetString(i18n.LocaleKey); locale != "" {
+		return locale
+	}
+	return translator.Negotiate(c.GetHeader("Accept-Language"))
+}
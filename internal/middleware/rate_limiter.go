@@ -1,15 +1,19 @@
 package middleware
 
 import (
-	"net/http"
 	"strconv"
 	"time"
 
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+
 	"github.com/gin-gonic/gin"
 	"github.com/ulule/limiter/v3"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
+// errRateLimited se registra cuando el cliente supera el límite de peticiones
+var errRateLimited = apperror.RateLimited("RATE_LIMITED", "Has excedido el límite de peticiones. Por favor, espera un momento.")
+
 // RateLimiterMiddleware crea un middleware para limitar las peticiones
 func RateLimiterMiddleware() gin.HandlerFunc {
 	// Crear un rate limiter que permita 100 peticiones por minuto
@@ -31,10 +35,7 @@ func RateLimiterMiddleware() gin.HandlerFunc {
 		// Obtener el contexto del limiter
 		context, err := limiterInstance.Get(c.Request.Context(), ip)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Error al verificar el límite de peticiones",
-			})
-			c.Abort()
+			abortWithError(c, apperror.Wrap(err, apperror.KindInternal, apperror.CodeInternal, "Error al verificar el límite de peticiones"))
 			return
 		}
 
@@ -45,10 +46,7 @@ func RateLimiterMiddleware() gin.HandlerFunc {
 
 		// Si se excedió el límite, retornar error
 		if context.Reached {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "Has excedido el límite de peticiones. Por favor, espera un momento.",
-			})
-			c.Abort()
+			abortWithError(c, errRateLimited)
 			return
 		}
 
@@ -0,0 +1,22 @@
+package application
+
+import "sync/atomic"
+
+// DrainState indica si la instancia se está apagando y ya no debe recibir tráfico
+type DrainState struct {
+	draining atomic.Bool
+}
+
+func NewDrainState() *DrainState {
+	return &DrainState{}
+}
+
+// Start marca el inicio del drenaje; a partir de aquí las sondas de readiness fallan
+func (s *DrainState) Start() {
+	s.draining.Store(true)
+}
+
+// IsDraining indica si el drenaje comenzó. Un estado nil nunca drena.
+func (s *DrainState) IsDraining() bool {
+	return s != nil && s.draining.Load()
+}
@@ -4,13 +4,23 @@ import (
 	"go-hexagonal-template/internal/modules/health/domain/model"
 )
 
-type HealthCheckUseCase struct{}
+type HealthCheckUseCase struct {
+	drain *DrainState
+}
 
-func NewHealthCheckUseCase() *HealthCheckUseCase {
-	return &HealthCheckUseCase{}
+func NewHealthCheckUseCase(drain *DrainState) *HealthCheckUseCase {
+	return &HealthCheckUseCase{
+		drain: drain,
+	}
 }
 
 func (uc *HealthCheckUseCase) Execute() *model.Health {
+	if uc.drain.IsDraining() {
+		return &model.Health{
+			Status:  model.StatusDown,
+			Message: "Apagando la instancia",
+		}
+	}
 	return &model.Health{
 		Status:  "UP",
 		Message: "¡Healthy!",
@@ -0,0 +1,20 @@
+package application
+
+import (
+	"go-hexagonal-template/internal/modules/health/domain/model"
+	"go-hexagonal-template/internal/modules/health/domain/port"
+)
+
+type PoolStatsUseCase struct {
+	provider port.PoolStatsProvider
+}
+
+func NewPoolStatsUseCase(provider port.PoolStatsProvider) *PoolStatsUseCase {
+	return &PoolStatsUseCase{
+		provider: provider,
+	}
+}
+
+func (uc *PoolStatsUseCase) Execute() (*model.PoolStats, error) {
+	return uc.provider.PoolStats()
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go-hexagonal-template/internal/modules/health/domain/model"
+	"go-hexagonal-template/internal/modules/health/domain/port"
+)
+
+// DefaultCheckTimeout es el plazo de los chequeos cuando no se indica otro
+const DefaultCheckTimeout = 2 * time.Second
+
+var (
+	// errCheckTimeout se informa cuando un chequeo no responde dentro de su plazo
+	errCheckTimeout = errors.New("el chequeo superó el tiempo máximo")
+	// errDraining se informa mientras la instancia se apaga
+	errDraining = errors.New("la instancia se está apagando")
+)
+
+// checkFunc adapta una función al puerto Checker
+type checkFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// NewChecker crea un chequeo a partir de una función, útil para los chequeos propios de cada módulo
+func NewChecker(name string, check func(ctx context.Context) error) port.Checker {
+	return &checkFunc{name: name, check: check}
+}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+type registeredCheck struct {
+	checker port.Checker
+	timeout time.Duration
+}
+
+type ProbeUseCase struct {
+	checks         []registeredCheck
+	defaultTimeout time.Duration
+	cacheTTL       time.Duration
+	drain          *DrainState
+
+	mu        sync.Mutex
+	cached    *model.Health
+	expiresAt time.Time
+}
+
+func NewProbeUseCase(defaultTimeout, cacheTTL time.Duration) *ProbeUseCase {
+	if defaultTimeout <= 0 {
+		defaultTimeout = DefaultCheckTimeout
+	}
+	return &ProbeUseCase{
+		defaultTimeout: defaultTimeout,
+		cacheTTL:       cacheTTL,
+	}
+}
+
+// Register agrega un chequeo a la sonda. Un timeout cero usa el plazo por defecto.
+func (uc *ProbeUseCase) Register(checker port.Checker, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = uc.defaultTimeout
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.checks = append(uc.checks, registeredCheck{checker: checker, timeout: timeout})
+	uc.cached = nil
+}
+
+// WatchDrain hace que la sonda falle en cuanto comience el drenaje, sin esperar a que venza la caché
+func (uc *ProbeUseCase) WatchDrain(drain *DrainState) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.drain = drain
+}
+
+// Execute ejecuta los chequeos en paralelo. El resultado se reutiliza durante cacheTTL
+// para que las sondas frecuentes no saturen las dependencias.
+func (uc *ProbeUseCase) Execute(ctx context.Context) *model.Health {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := time.Now()
+	if uc.drain.IsDraining() {
+		return &model.Health{
+			Status:    model.StatusDown,
+			Checks:    []model.CheckResult{{Name: "shutdown", Status: model.StatusDown, Error: errDraining.Error()}},
+			CheckedAt: &now,
+		}
+	}
+	if uc.cached != nil && now.Before(uc.expiresAt) {
+		return uc.cached
+	}
+
+	// El resultado se comparte entre peticiones: que un cliente cancele no debe marcarlo como caído
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]model.CheckResult, len(uc.checks))
+	var wg sync.WaitGroup
+	for i, check := range uc.checks {
+		wg.Add(1)
+		go func(i int, check registeredCheck) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	health := &model.Health{
+		Status:    model.StatusUp,
+		Checks:    results,
+		CheckedAt: &now,
+	}
+	for _, result := range results {
+		if result.Status != model.StatusUp {
+			health.Status = model.StatusDown
+			break
+		}
+	}
+
+	uc.cached = health
+	uc.expiresAt = now.Add(uc.cacheTTL)
+	return health
+}
+
+// runCheck ejecuta un chequeo con su plazo; si el chequeo ignora el contexto se abandona al vencer
+func runCheck(ctx context.Context, check registeredCheck) model.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, check.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errCheckTimeout
+	}
+
+	result := model.CheckResult{
+		Name:      check.checker.Name(),
+		Status:    model.StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = errCheckTimeout
+		}
+		result.Status = model.StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -1,6 +1,29 @@
 package model
 
+import "time"
+
+// Estados posibles de un chequeo o de una sonda
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
 type Health struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
+	Status    string        `json:"status"`
+	Message   string        `json:"message,omitempty"`
+	Checks    []CheckResult `json:"checks,omitempty"`
+	CheckedAt *time.Time    `json:"checked_at,omitempty"`
+}
+
+// IsUp indica si la sonda y todos sus chequeos están operativos
+func (h *Health) IsUp() bool {
+	return h.Status == StatusUp
+}
+
+// CheckResult es el resultado de ejecutar un chequeo de dependencia
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
 }
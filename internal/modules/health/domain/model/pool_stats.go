@@ -0,0 +1,17 @@
+package model
+
+// PoolStats resume el estado del pool de conexiones a la base de datos principal
+type PoolStats struct {
+	// MaxOpenConnections es el límite configurado; 0 significa sin límite
+	MaxOpenConnections int `json:"max_open_connections"`
+	OpenConnections    int `json:"open_connections"`
+	InUse              int `json:"in_use"`
+	Idle               int `json:"idle"`
+	// WaitCount y WaitDurationMs acumulan las esperas por una conexión libre desde el arranque
+	WaitCount      int64   `json:"wait_count"`
+	WaitDurationMs float64 `json:"wait_duration_ms"`
+	// Conexiones cerradas por DB_MAX_IDLE_CONNS, DB_CONN_MAX_IDLE_TIME y DB_CONN_MAX_LIFETIME
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+}
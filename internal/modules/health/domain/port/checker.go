@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// Checker verifica una dependencia de la aplicación (base de datos, disco, servicios externos).
+// Check debe respetar la cancelación del contexto.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
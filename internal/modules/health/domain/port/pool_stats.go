@@ -0,0 +1,8 @@
+package port
+
+import "go-hexagonal-template/internal/modules/health/domain/model"
+
+// PoolStatsProvider expone las estadísticas del pool de conexiones a la base de datos
+type PoolStatsProvider interface {
+	PoolStats() (*model.PoolStats, error)
+}
@@ -0,0 +1,59 @@
+package checks
+
+import (
+	"context"
+
+	"go-hexagonal-template/internal/modules/health/domain/model"
+	"go-hexagonal-template/internal/modules/health/domain/port"
+
+	"gorm.io/gorm"
+)
+
+type DatabaseChecker struct {
+	db *gorm.DB
+}
+
+// NewDatabaseChecker crea un chequeo que hace ping a la base de datos de GORM
+func NewDatabaseChecker(db *gorm.DB) port.Checker {
+	return &DatabaseChecker{db: db}
+}
+
+func (c *DatabaseChecker) Name() string {
+	return "database"
+}
+
+func (c *DatabaseChecker) Check(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+type DatabasePoolStats struct {
+	db *gorm.DB
+}
+
+// NewDatabasePoolStats lee las estadísticas del pool de database/sql de la conexión principal
+func NewDatabasePoolStats(db *gorm.DB) port.PoolStatsProvider {
+	return &DatabasePoolStats{db: db}
+}
+
+func (s *DatabasePoolStats) PoolStats() (*model.PoolStats, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	stats := sqlDB.Stats()
+	return &model.PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     float64(stats.WaitDuration.Microseconds()) / 1000,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"go-hexagonal-template/internal/modules/health/domain/port"
+)
+
+type DiskSpaceChecker struct {
+	path         string
+	minFreeBytes uint64
+}
+
+// NewDiskSpaceChecker crea un chequeo que falla cuando el espacio libre en path baja de minFreeBytes
+func NewDiskSpaceChecker(path string, minFreeBytes uint64) port.Checker {
+	return &DiskSpaceChecker{path: path, minFreeBytes: minFreeBytes}
+}
+
+func (c *DiskSpaceChecker) Name() string {
+	return "disk"
+}
+
+func (c *DiskSpaceChecker) Check(ctx context.Context) error {
+	free, err := freeBytes(c.path)
+	if err != nil {
+		return err
+	}
+	if free < c.minFreeBytes {
+		return fmt.Errorf("espacio libre insuficiente en %s: %d MB disponibles, mínimo %d MB", c.path, free>>20, c.minFreeBytes>>20)
+	}
+	return nil
+}
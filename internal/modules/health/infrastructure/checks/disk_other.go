@@ -0,0 +1,10 @@
+//go:build !unix
+
+package checks
+
+import "errors"
+
+// freeBytes no está disponible fuera de sistemas Unix
+func freeBytes(path string) (uint64, error) {
+	return 0, errors.New("chequeo de disco no soportado en este sistema")
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package checks
+
+import "syscall"
+
+// freeBytes devuelve el espacio disponible para usuarios sin privilegios
+func freeBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,20 @@
+// Package modules enumera los módulos que componen la aplicación. Añadir un módulo
+// consiste en incluir su constructor en All; main.go y la configuración no cambian.
+package modules
+
+import (
+	"go-hexagonal-template/internal/infrastructure/events"
+	"go-hexagonal-template/internal/infrastructure/module"
+	"go-hexagonal-template/internal/infrastructure/outbox"
+	"go-hexagonal-template/internal/modules/user"
+)
+
+// All devuelve los módulos en el orden en que se inicializan y registran sus rutas.
+// Los módulos que reaccionan a eventos de otros reciben bus y se suscriben en su constructor.
+func All() []module.Module {
+	bus := events.NewBus()
+	return []module.Module{
+		outbox.NewModule(bus),
+		user.NewModule(),
+	}
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/shared/domain/port"
+)
+
+// OwnershipPolicy permite a cada usuario actuar sobre sus propios recursos y
+// a quien tenga el permiso asociado a la acción actuar sobre cualquiera
+type OwnershipPolicy struct {
+	permissions map[string]string
+}
+
+// NewOwnershipPolicy crea la política a partir del permiso que concede cada acción
+// sobre recursos ajenos; las acciones sin permiso asociado solo las puede realizar el dueño
+func NewOwnershipPolicy(permissions map[string]string) port.Policy {
+	return &OwnershipPolicy{
+		permissions: permissions,
+	}
+}
+
+// Authorize implementa el método Authorize de la interfaz Policy
+func (p *OwnershipPolicy) Authorize(principal *model.Principal, action string, resource model.Resource) error {
+	if principal == nil {
+		return model.ErrForbidden
+	}
+
+	if permission, ok := p.permissions[action]; ok && principal.HasPermission(permission) {
+		return nil
+	}
+
+	if resource.OwnerID != 0 && resource.OwnerID == principal.UserID {
+		return nil
+	}
+
+	return model.ErrForbidden
+}
@@ -0,0 +1,118 @@
+package apperror
+
+import "errors"
+
+// Kind clasifica un error de la aplicación según su naturaleza
+type Kind string
+
+// Tipos de error soportados por el catálogo
+const (
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindRateLimited  Kind = "rate_limited"
+	KindInternal     Kind = "internal"
+)
+
+// Códigos genéricos compartidos por todos los módulos
+const (
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInternal         = "INTERNAL_ERROR"
+)
+
+// FieldError describe el fallo de validación de un campo concreto. Code es la regla
+// incumplida y Param su argumento (por ejemplo min=6); Message se traduce al responder.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error es un error tipado con un código estable que los adaptadores pueden traducir
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// Error implementa la interfaz error
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap expone el error original para errors.Is y errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New crea un error del tipo indicado
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap crea un error del tipo indicado que conserva el error original
+func Wrap(err error, kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+// NotFound crea un error para recursos inexistentes
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict crea un error para operaciones que chocan con el estado actual
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Validation crea un error para datos de entrada inválidos
+func Validation(code, message string, fields ...FieldError) *Error {
+	err := New(KindValidation, code, message)
+	err.Fields = fields
+	return err
+}
+
+// Unauthorized crea un error para peticiones sin credenciales válidas
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// Forbidden crea un error para accesos denegados
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// RateLimited crea un error para clientes que superan el límite de peticiones
+func RateLimited(code, message string) *Error {
+	return New(KindRateLimited, code, message)
+}
+
+// Internal crea un error inesperado que no debe exponer detalles al cliente
+func Internal(code, message string) *Error {
+	return New(KindInternal, code, message)
+}
+
+// As busca un *Error en la cadena de err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// KindOf devuelve el tipo del error; los errores fuera del catálogo se consideran internos
+func KindOf(err error) Kind {
+	if appErr, ok := As(err); ok {
+		return appErr.Kind
+	}
+	return KindInternal
+}
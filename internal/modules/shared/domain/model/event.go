@@ -0,0 +1,21 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event es un hecho ocurrido en el dominio. EventName identifica su tipo y el resto
+// de campos forman el contenido que reciben los suscriptores.
+type Event interface {
+	EventName() string
+}
+
+// EventMessage es un evento serializado tal y como se guarda en el outbox y se publica.
+// La entrega es al menos una vez: los destinatarios deben deduplicar por ID.
+type EventMessage struct {
+	ID         uint            `json:"id"`
+	Name       string          `json:"name"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// Principal representa al usuario autenticado que ejecuta un caso de uso
+type Principal struct {
+	UserID      uint
+	Roles       []string
+	Permissions []string
+}
+
+// HasPermission indica si el principal tiene el permiso indicado
+func (p *Principal) HasPermission(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
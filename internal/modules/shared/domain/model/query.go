@@ -0,0 +1,117 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+)
+
+// Límites de paginación
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+var (
+	// ErrInvalidQuery se retorna cuando los parámetros de una consulta no son válidos
+	ErrInvalidQuery error = apperror.Validation("INVALID_QUERY", "consulta inválida")
+	// ErrInvalidCursor se retorna cuando el cursor no se puede decodificar o no corresponde al orden pedido
+	ErrInvalidCursor error = apperror.Validation("INVALID_CURSOR", "cursor inválido")
+)
+
+// Sort indica el campo por el que se ordena un listado
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSort interpreta un orden con el formato "campo" o "-campo" (descendente)
+func ParseSort(value string) Sort {
+	if strings.HasPrefix(value, "-") {
+		return Sort{Field: strings.TrimPrefix(value, "-"), Descending: true}
+	}
+	return Sort{Field: value}
+}
+
+// String retorna el orden con el mismo formato que acepta ParseSort
+func (s Sort) String() string {
+	if s.Descending {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Validate verifica que el campo de orden esté entre los permitidos
+func (s Sort) Validate(allowed []string) error {
+	for _, field := range allowed {
+		if s.Field == field {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no se puede ordenar por %q", ErrInvalidQuery, s.Field)
+}
+
+// PageRequest define la página pedida, por desplazamiento (Offset) o por cursor
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+// Normalize aplica el límite por defecto, acota el máximo y valida la combinación de parámetros
+func (p *PageRequest) Normalize() error {
+	if p.Limit < 0 || p.Offset < 0 {
+		return fmt.Errorf("%w: limit y offset no pueden ser negativos", ErrInvalidQuery)
+	}
+	if p.Cursor != "" && p.Offset > 0 {
+		return fmt.Errorf("%w: cursor y offset no se pueden combinar", ErrInvalidQuery)
+	}
+	if p.Limit == 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	return nil
+}
+
+// Page es una página de resultados. Total solo se calcula en la paginación por desplazamiento.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
+// Cursor identifica la posición del último elemento de una página para la paginación keyset.
+// Incluye el orden con el que se generó para rechazarlo si la consulta cambia.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// Encode serializa el cursor en un valor opaco apto para URLs
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor interpreta un cursor generado por Encode y verifica que corresponda al orden indicado
+func DecodeCursor(value string, sort Sort) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if cursor.Sort != sort.String() || cursor.ID == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
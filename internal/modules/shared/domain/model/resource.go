@@ -0,0 +1,20 @@
+package model
+
+import "go-hexagonal-template/internal/modules/shared/domain/apperror"
+
+// Acciones genéricas evaluadas por las políticas
+const (
+	ActionRead   = "read"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// ErrForbidden se retorna cuando una política deniega el acceso
+var ErrForbidden error = apperror.Forbidden("FORBIDDEN", "no tienes permisos para realizar esta acción")
+
+// Resource identifica el recurso sobre el que se evalúa una política
+type Resource struct {
+	Type    string
+	ID      uint
+	OwnerID uint
+}
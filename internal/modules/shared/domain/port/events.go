@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+)
+
+// EventOutbox guarda eventos para publicarlos más tarde. Dentro de TxManager.WithinTx
+// los eventos se guardan en la misma transacción que el resto de escrituras.
+type EventOutbox interface {
+	Add(ctx context.Context, events ...model.Event) error
+}
+
+// EventPublisher entrega un evento a sus destinatarios. Un error hace que el evento
+// se reintente más tarde, así que puede recibirse más de una vez.
+type EventPublisher interface {
+	Publish(ctx context.Context, message model.EventMessage) error
+}
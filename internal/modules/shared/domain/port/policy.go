@@ -0,0 +1,9 @@
+package port
+
+import "go-hexagonal-template/internal/modules/shared/domain/model"
+
+// Policy decide si un principal puede ejecutar una acción sobre un recurso.
+// Retorna model.ErrForbidden cuando el acceso se deniega.
+type Policy interface {
+	Authorize(principal *model.Principal, action string, resource model.Resource) error
+}
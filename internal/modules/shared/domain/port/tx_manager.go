@@ -0,0 +1,12 @@
+package port
+
+import "context"
+
+// TxManager ejecuta varias operaciones de repositorio como una unidad atómica.
+// Los repositorios que reciben el contexto de fn participan en la transacción;
+// si fn retorna un error se revierte todo lo que hizo.
+type TxManager interface {
+	// WithinTx ejecuta fn en una transacción. Dentro de otra transacción crea un savepoint,
+	// así que un error en fn solo revierte sus propios cambios.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
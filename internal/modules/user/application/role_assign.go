@@ -0,0 +1,28 @@
+package application
+
+import (
+	"context"
+
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+// AssignRoleUseCase asigna un rol a un usuario existente
+type AssignRoleUseCase struct {
+	userRepository port.UserRepository
+	roleRepository port.RoleRepository
+}
+
+func NewAssignRoleUseCase(userRepository port.UserRepository, roleRepository port.RoleRepository) *AssignRoleUseCase {
+	return &AssignRoleUseCase{
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+	}
+}
+
+// Execute asigna roleName al usuario; asignar un rol que ya tiene no hace nada
+func (uc *AssignRoleUseCase) Execute(ctx context.Context, userID uint, roleName string) error {
+	if _, err := uc.userRepository.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	return uc.roleRepository.AssignToUser(ctx, userID, roleName)
+}
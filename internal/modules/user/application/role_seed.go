@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type SeedRolesUseCase struct {
+	userRepository port.UserRepository
+	roleRepository port.RoleRepository
+	txManager      sharedport.TxManager
+}
+
+func NewSeedRolesUseCase(userRepository port.UserRepository, roleRepository port.RoleRepository, txManager sharedport.TxManager) *SeedRolesUseCase {
+	return &SeedRolesUseCase{
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+		txManager:      txManager,
+	}
+}
+
+// SeedRolesInput define el administrador inicial; si Email está vacío no se crea ninguno
+type SeedRolesInput struct {
+	AdminEmail    string
+	AdminName     string
+	AdminPassword string
+}
+
+// Motivos por los que la semilla no crea el administrador inicial ni le da el rol admin
+var (
+	// ErrSeedAdminDeleted indica que el usuario de ADMIN_EMAIL está eliminado; no se restaura
+	// porque alguien lo deshabilitó a propósito
+	ErrSeedAdminDeleted = errors.New("el usuario existe pero está eliminado; se omite")
+	// ErrSeedAdminNotAdmin indica que el email pertenece a una cuenta que la semilla no creó,
+	// por ejemplo una registrada con POST /users; no se le concede admin automáticamente
+	ErrSeedAdminNotAdmin = errors.New("el email pertenece a una cuenta existente sin el rol admin; asígnalo de forma explícita")
+)
+
+// SeedRolesOutput informa de si el administrador inicial se omitió
+type SeedRolesOutput struct {
+	// AdminSkipped es ErrSeedAdminDeleted o ErrSeedAdminNotAdmin si no se creó ni se promovió
+	// el administrador; nil en otro caso
+	AdminSkipped error
+}
+
+// Execute crea los roles por defecto y el administrador inicial en una sola transacción. Es idempotente.
+func (uc *SeedRolesUseCase) Execute(ctx context.Context, input SeedRolesInput) (*SeedRolesOutput, error) {
+	output := &SeedRolesOutput{}
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		output.AdminSkipped, err = uc.seed(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (uc *SeedRolesUseCase) seed(ctx context.Context, input SeedRolesInput) (skipped error, err error) {
+	for _, role := range model.DefaultRoles() {
+		if _, err := uc.roleRepository.Save(ctx, &role); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.AdminEmail == "" {
+		return nil, nil
+	}
+
+	// Se buscan también los eliminados: su email sigue ocupado y crearlo de nuevo fallaría
+	email := model.NormalizeEmail(input.AdminEmail)
+	existing, err := uc.userRepository.GetByEmailIncludingDeleted(ctx, email)
+	if err == nil {
+		return uc.checkExistingAdmin(ctx, existing)
+	}
+	if !errors.Is(err, model.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// El administrador aún no existe: crearlo
+	if len(input.AdminPassword) < 6 {
+		return nil, errors.New("la contraseña del administrador inicial debe tener al menos 6 caracteres")
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.AdminPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	name := input.AdminName
+	if name == "" {
+		name = "Administrador"
+	}
+	admin, err := uc.userRepository.Create(ctx, &model.User{
+		Email:     email,
+		Name:      name,
+		Password:  string(hashedPassword),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, uc.roleRepository.AssignToUser(ctx, admin.ID, model.RoleAdmin)
+}
+
+// checkExistingAdmin no modifica una cuenta existente: solo la semilla concede admin a la
+// cuenta que ella misma crea, para no escalar una cuenta registrada por cualquiera
+func (uc *SeedRolesUseCase) checkExistingAdmin(ctx context.Context, user *model.User) (skipped error, err error) {
+	if user.DeletedAt.Valid {
+		return ErrSeedAdminDeleted, nil
+	}
+	roles, err := uc.roleRepository.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.Name == model.RoleAdmin {
+			return nil, nil
+		}
+	}
+	return ErrSeedAdminNotAdmin, nil
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+// TokenPair agrupa el token de acceso y el refresh token entregados al cliente
+type TokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// TokenIssuer emite pares de tokens incluyendo los roles y permisos vigentes del usuario
+type TokenIssuer struct {
+	tokenManager           *auth.TokenManager
+	refreshTokenRepository port.RefreshTokenRepository
+	roleRepository         port.RoleRepository
+}
+
+func NewTokenIssuer(tokenManager *auth.TokenManager, refreshTokenRepository port.RefreshTokenRepository, roleRepository port.RoleRepository) *TokenIssuer {
+	return &TokenIssuer{
+		tokenManager:           tokenManager,
+		refreshTokenRepository: refreshTokenRepository,
+		roleRepository:         roleRepository,
+	}
+}
+
+// Issue genera un token de acceso y persiste un nuevo refresh token dentro de la familia indicada
+func (i *TokenIssuer) Issue(ctx context.Context, user *model.User, familyID string) (*TokenPair, error) {
+	roles, err := i.roleRepository.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := auth.TokenSubject{
+		UserID:    fmt.Sprintf("%d", user.ID),
+		Email:     user.Email,
+		SessionID: familyID,
+	}
+	seen := map[string]bool{}
+	for _, role := range roles {
+		subject.Roles = append(subject.Roles, role.Name)
+		for _, permission := range role.PermissionNames() {
+			if !seen[permission] {
+				seen[permission] = true
+				subject.Permissions = append(subject.Permissions, permission)
+			}
+		}
+	}
+
+	accessToken, err := i.tokenManager.GenerateToken(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshTokenHash, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	_, err = i.refreshTokenRepository.Create(ctx, &model.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: refreshTokenHash,
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(i.tokenManager.RefreshTokenTTL()),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(i.tokenManager.AccessTokenTTL().Seconds()),
+	}, nil
+}
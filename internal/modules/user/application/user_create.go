@@ -1,8 +1,11 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"time"
 
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
 	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/port"
 
@@ -11,11 +14,17 @@ import (
 
 type CreateUserUseCase struct {
 	userRepository port.UserRepository
+	roleRepository port.RoleRepository
+	txManager      sharedport.TxManager
+	outbox         sharedport.EventOutbox
 }
 
-func NewCreateUserUseCase(userRepository port.UserRepository) *CreateUserUseCase {
+func NewCreateUserUseCase(userRepository port.UserRepository, roleRepository port.RoleRepository, txManager sharedport.TxManager, outbox sharedport.EventOutbox) *CreateUserUseCase {
 	return &CreateUserUseCase{
 		userRepository: userRepository,
+		roleRepository: roleRepository,
+		txManager:      txManager,
+		outbox:         outbox,
 	}
 }
 
@@ -25,7 +34,15 @@ type CreateUserInput struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
-func (uc *CreateUserUseCase) Execute(input CreateUserInput) (*model.User, error) {
+func (uc *CreateUserUseCase) Execute(ctx context.Context, input CreateUserInput) (*model.User, error) {
+	email := model.NormalizeEmail(input.Email)
+
+	// Comprobar el email antes de hashear la contraseña; la restricción única
+	// de la base de datos cubre las altas concurrentes
+	if err := ensureEmailAvailable(ctx, uc.userRepository, email, 0); err != nil {
+		return nil, err
+	}
+
 	// Hashear la contraseña
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -33,12 +50,42 @@ func (uc *CreateUserUseCase) Execute(input CreateUserInput) (*model.User, error)
 	}
 
 	user := &model.User{
-		Email:     input.Email,
+		Email:     email,
 		Name:      input.Name,
 		Password:  string(hashedPassword),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	return uc.userRepository.Create(user)
+	// El alta, el rol básico y el evento se guardan juntos: un fallo al asignar el rol
+	// no deja un usuario sin permisos ni un evento de un alta que no existe
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepository.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := uc.roleRepository.AssignToUser(ctx, user.ID, model.RoleUser); err != nil {
+			return err
+		}
+		return uc.outbox.Add(ctx, model.UserRegistered{UserID: user.ID, Email: user.Email})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ensureEmailAvailable retorna ErrEmailTaken si el email pertenece a un usuario distinto de ownerID
+func ensureEmailAvailable(ctx context.Context, userRepository port.UserRepository, email string, ownerID uint) error {
+	existing, err := userRepository.GetByEmail(ctx, email)
+	if errors.Is(err, model.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != ownerID {
+		return model.ErrEmailTaken
+	}
+	return nil
 }
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+type DeleteUserUseCase struct {
+	userRepository         port.UserRepository
+	policy                 sharedport.Policy
+	revocationStore        port.TokenRevocationStore
+	refreshTokenRepository port.RefreshTokenRepository
+	txManager              sharedport.TxManager
+}
+
+func NewDeleteUserUseCase(userRepository port.UserRepository, policy sharedport.Policy, revocationStore port.TokenRevocationStore, refreshTokenRepository port.RefreshTokenRepository, txManager sharedport.TxManager) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		userRepository:         userRepository,
+		policy:                 policy,
+		revocationStore:        revocationStore,
+		refreshTokenRepository: refreshTokenRepository,
+		txManager:              txManager,
+	}
+}
+
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, principal *sharedmodel.Principal, id uint) error {
+	if err := uc.policy.Authorize(principal, sharedmodel.ActionDelete, userResource(id)); err != nil {
+		return err
+	}
+
+	// Un usuario eliminado no debe conservar sesiones abiertas: si falla una revocación
+	// tampoco se elimina
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return revokeSessions(ctx, uc.revocationStore, uc.refreshTokenRepository, id)
+	})
+}
+
+type RestoreUserUseCase struct {
+	userRepository port.UserRepository
+}
+
+func NewRestoreUserUseCase(userRepository port.UserRepository) *RestoreUserUseCase {
+	return &RestoreUserUseCase{
+		userRepository: userRepository,
+	}
+}
+
+func (uc *RestoreUserUseCase) Execute(ctx context.Context, id uint) (*model.User, error) {
+	return uc.userRepository.Restore(ctx, id)
+}
+
+type HardDeleteUserUseCase struct {
+	userRepository         port.UserRepository
+	revocationStore        port.TokenRevocationStore
+	refreshTokenRepository port.RefreshTokenRepository
+	txManager              sharedport.TxManager
+}
+
+func NewHardDeleteUserUseCase(userRepository port.UserRepository, revocationStore port.TokenRevocationStore, refreshTokenRepository port.RefreshTokenRepository, txManager sharedport.TxManager) *HardDeleteUserUseCase {
+	return &HardDeleteUserUseCase{
+		userRepository:         userRepository,
+		revocationStore:        revocationStore,
+		refreshTokenRepository: refreshTokenRepository,
+		txManager:              txManager,
+	}
+}
+
+func (uc *HardDeleteUserUseCase) Execute(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepository.HardDelete(ctx, id); err != nil {
+			return err
+		}
+		return revokeSessions(ctx, uc.revocationStore, uc.refreshTokenRepository, id)
+	})
+}
+
+// revokeSessions revoca los tokens de acceso emitidos hasta ahora y todas las familias de
+// refresh tokens del usuario
+func revokeSessions(ctx context.Context, revocationStore port.TokenRevocationStore, refreshTokenRepository port.RefreshTokenRepository, userID uint) error {
+	now := time.Now()
+	if err := revocationStore.RevokeAllForUser(ctx, userID, now); err != nil {
+		return err
+	}
+	return refreshTokenRepository.RevokeAllForUser(ctx, userID, now)
+}
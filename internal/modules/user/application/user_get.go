@@ -1,20 +1,56 @@
 package application
 
 import (
+	"context"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
 	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/port"
 )
 
 type GetUserUseCase struct {
 	userRepository port.UserRepository
+	policy         sharedport.Policy
 }
 
-func NewGetUserUseCase(userRepository port.UserRepository) *GetUserUseCase {
+func NewGetUserUseCase(userRepository port.UserRepository, policy sharedport.Policy) *GetUserUseCase {
 	return &GetUserUseCase{
 		userRepository: userRepository,
+		policy:         policy,
+	}
+}
+
+func (uc *GetUserUseCase) Execute(ctx context.Context, principal *sharedmodel.Principal, id uint) (*model.User, error) {
+	// Autorizar antes de consultar para no revelar si el usuario existe
+	if err := uc.policy.Authorize(principal, sharedmodel.ActionRead, userResource(id)); err != nil {
+		return nil, err
+	}
+
+	return uc.userRepository.GetByID(ctx, id)
+}
+
+// FindUserByEmailUseCase busca un usuario por email. No se expone por HTTP porque,
+// a diferencia de GetUserUseCase, necesita consultar antes de autorizar.
+type FindUserByEmailUseCase struct {
+	userRepository port.UserRepository
+	policy         sharedport.Policy
+}
+
+func NewFindUserByEmailUseCase(userRepository port.UserRepository, policy sharedport.Policy) *FindUserByEmailUseCase {
+	return &FindUserByEmailUseCase{
+		userRepository: userRepository,
+		policy:         policy,
 	}
 }
 
-func (uc *GetUserUseCase) Execute(id uint) (*model.User, error) {
-	return uc.userRepository.GetByID(id)
+func (uc *FindUserByEmailUseCase) Execute(ctx context.Context, principal *sharedmodel.Principal, email string) (*model.User, error) {
+	user, err := uc.userRepository.GetByEmail(ctx, model.NormalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.policy.Authorize(principal, sharedmodel.ActionRead, userResource(user.ID)); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
@@ -0,0 +1,26 @@
+package application
+
+import (
+	"context"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+type ListUsersUseCase struct {
+	userRepository port.UserRepository
+}
+
+func NewListUsersUseCase(userRepository port.UserRepository) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userRepository: userRepository,
+	}
+}
+
+func (uc *ListUsersUseCase) Execute(ctx context.Context, query model.UserQuery) (*sharedmodel.Page[model.User], error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	return uc.userRepository.List(ctx, query)
+}
@@ -1,23 +1,34 @@
 package application
 
 import (
+	"context"
 	"errors"
-	"fmt"
 
 	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
 	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/port"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials se retorna cuando el email no existe o la contraseña no coincide
+var ErrInvalidCredentials error = apperror.Unauthorized("INVALID_CREDENTIALS", "credenciales inválidas")
+
 type LoginUserUseCase struct {
 	userRepository port.UserRepository
+	tokenIssuer    *TokenIssuer
+	txManager      sharedport.TxManager
+	outbox         sharedport.EventOutbox
 }
 
-func NewLoginUserUseCase(userRepository port.UserRepository) *LoginUserUseCase {
+func NewLoginUserUseCase(userRepository port.UserRepository, tokenIssuer *TokenIssuer, txManager sharedport.TxManager, outbox sharedport.EventOutbox) *LoginUserUseCase {
 	return &LoginUserUseCase{
 		userRepository: userRepository,
+		tokenIssuer:    tokenIssuer,
+		txManager:      txManager,
+		outbox:         outbox,
 	}
 }
 
@@ -27,31 +38,47 @@ type LoginUserInput struct {
 }
 
 type LoginUserOutput struct {
-	Token string      `json:"token"`
-	User  *model.User `json:"user"`
+	TokenPair
+	User *model.User `json:"user"`
 }
 
-func (uc *LoginUserUseCase) Execute(input LoginUserInput) (*LoginUserOutput, error) {
+func (uc *LoginUserUseCase) Execute(ctx context.Context, input LoginUserInput) (*LoginUserOutput, error) {
 	// Buscar el usuario por email
-	user, err := uc.userRepository.GetByEmail(input.Email)
+	user, err := uc.userRepository.GetByEmail(ctx, model.NormalizeEmail(input.Email))
+	if errors.Is(err, model.ErrUserNotFound) {
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
-		return nil, errors.New("credenciales inválidas")
+		return nil, err
 	}
 
 	// Verificar la contraseña
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password))
 	if err != nil {
-		return nil, errors.New("credenciales inválidas")
+		return nil, ErrInvalidCredentials
+	}
+
+	// Cada login inicia una nueva familia de refresh tokens
+	familyID, err := auth.GenerateTokenID()
+	if err != nil {
+		return nil, err
 	}
 
-	// Generar el token JWT
-	token, err := auth.GenerateToken(fmt.Sprintf("%d", user.ID), user.Email)
+	// Generar el token JWT y el refresh token junto con el evento de inicio de sesión
+	var tokens *TokenPair
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if tokens, err = uc.tokenIssuer.Issue(ctx, user, familyID); err != nil {
+			return err
+		}
+		return uc.outbox.Add(ctx, model.UserLoggedIn{UserID: user.ID, SessionID: familyID})
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &LoginUserOutput{
-		Token: token,
-		User:  user,
+		TokenPair: *tokens,
+		User:      user,
 	}, nil
 }
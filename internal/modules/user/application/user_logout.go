@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+type LogoutUseCase struct {
+	revocationStore        port.TokenRevocationStore
+	refreshTokenRepository port.RefreshTokenRepository
+	txManager              sharedport.TxManager
+}
+
+func NewLogoutUseCase(revocationStore port.TokenRevocationStore, refreshTokenRepository port.RefreshTokenRepository, txManager sharedport.TxManager) *LogoutUseCase {
+	return &LogoutUseCase{
+		revocationStore:        revocationStore,
+		refreshTokenRepository: refreshTokenRepository,
+		txManager:              txManager,
+	}
+}
+
+type LogoutInput struct {
+	TokenID   string
+	UserID    uint
+	SessionID string
+	ExpiresAt time.Time
+}
+
+// Execute revoca el token de acceso y su sesión en una transacción: si una revocación falla,
+// el cliente puede reintentar sin quedar con la sesión cerrada a medias
+func (uc *LogoutUseCase) Execute(ctx context.Context, input LogoutInput) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Revocar el token de acceso presentado
+		if err := uc.revocationStore.Revoke(ctx, input.TokenID, input.UserID, input.ExpiresAt); err != nil {
+			return err
+		}
+
+		// Revocar los refresh tokens de la misma sesión
+		if input.SessionID == "" {
+			return nil
+		}
+		return uc.refreshTokenRepository.RevokeFamily(ctx, input.SessionID, time.Now())
+	})
+}
+
+type LogoutAllUseCase struct {
+	revocationStore        port.TokenRevocationStore
+	refreshTokenRepository port.RefreshTokenRepository
+	txManager              sharedport.TxManager
+}
+
+func NewLogoutAllUseCase(revocationStore port.TokenRevocationStore, refreshTokenRepository port.RefreshTokenRepository, txManager sharedport.TxManager) *LogoutAllUseCase {
+	return &LogoutAllUseCase{
+		revocationStore:        revocationStore,
+		refreshTokenRepository: refreshTokenRepository,
+		txManager:              txManager,
+	}
+}
+
+// Execute invalida los tokens de acceso emitidos hasta ahora y todas las sesiones abiertas
+// del usuario en una misma transacción
+func (uc *LogoutAllUseCase) Execute(ctx context.Context, userID uint) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return revokeSessions(ctx, uc.revocationStore, uc.refreshTokenRepository, userID)
+	})
+}
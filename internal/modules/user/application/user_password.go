@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+// ResetPasswordUseCase reemplaza la contraseña de un usuario y cierra todas sus sesiones
+type ResetPasswordUseCase struct {
+	patchUserUseCase *PatchUserUseCase
+	logoutAllUseCase *LogoutAllUseCase
+	txManager        sharedport.TxManager
+}
+
+func NewResetPasswordUseCase(userRepository port.UserRepository, policy sharedport.Policy, revocationStore port.TokenRevocationStore, refreshTokenRepository port.RefreshTokenRepository, txManager sharedport.TxManager, outbox sharedport.EventOutbox) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		patchUserUseCase: NewPatchUserUseCase(userRepository, policy, txManager, outbox),
+		logoutAllUseCase: NewLogoutAllUseCase(revocationStore, refreshTokenRepository, txManager),
+		txManager:        txManager,
+	}
+}
+
+// Execute cambia la contraseña y cierra las sesiones en una transacción, para que no quede
+// la contraseña nueva con las sesiones anteriores abiertas
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, principal *sharedmodel.Principal, id uint, password string) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.patchUserUseCase.Execute(ctx, principal, id, PatchUserInput{Password: &password}); err != nil {
+			return err
+		}
+
+		// Quien conocía la contraseña anterior no debe conservar sesiones abiertas
+		return uc.logoutAllUseCase.Execute(ctx, id)
+	})
+}
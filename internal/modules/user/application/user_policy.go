@@ -0,0 +1,30 @@
+package application
+
+import (
+	sharedapplication "go-hexagonal-template/internal/modules/shared/application"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+)
+
+// UserResourceType identifica a los usuarios como recurso en las políticas
+const UserResourceType = "user"
+
+// NewUserPolicy crea la política de acceso a usuarios: cada usuario accede a sí
+// mismo y los permisos users:* permiten acceder a cualquiera
+func NewUserPolicy() sharedport.Policy {
+	return sharedapplication.NewOwnershipPolicy(map[string]string{
+		sharedmodel.ActionRead:   model.PermissionUsersRead,
+		sharedmodel.ActionUpdate: model.PermissionUsersWrite,
+		sharedmodel.ActionDelete: model.PermissionUsersDelete,
+	})
+}
+
+// userResource describe a un usuario como recurso; cada usuario es dueño de sí mismo
+func userResource(id uint) sharedmodel.Resource {
+	return sharedmodel.Resource{
+		Type:    UserResourceType,
+		ID:      id,
+		OwnerID: id,
+	}
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+var (
+	// ErrInvalidRefreshToken se retorna cuando el refresh token no existe, venció o fue revocado
+	ErrInvalidRefreshToken error = apperror.Unauthorized("INVALID_REFRESH_TOKEN", "refresh token inválido")
+	// ErrRefreshTokenReused se retorna cuando se presenta un refresh token ya usado
+	ErrRefreshTokenReused error = apperror.Unauthorized("REFRESH_TOKEN_REUSED", "refresh token reutilizado")
+)
+
+type RefreshTokenUseCase struct {
+	userRepository         port.UserRepository
+	refreshTokenRepository port.RefreshTokenRepository
+	tokenIssuer            *TokenIssuer
+	txManager              sharedport.TxManager
+}
+
+func NewRefreshTokenUseCase(userRepository port.UserRepository, refreshTokenRepository port.RefreshTokenRepository, tokenIssuer *TokenIssuer, txManager sharedport.TxManager) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		tokenIssuer:            tokenIssuer,
+		txManager:              txManager,
+	}
+}
+
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenInput) (*TokenPair, error) {
+	// Buscar el token por su hash
+	stored, err := uc.refreshTokenRepository.GetByHash(ctx, auth.HashRefreshToken(input.RefreshToken))
+	if errors.Is(err, model.ErrRefreshTokenNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if stored.IsRevoked() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Un token ya usado indica que fue robado: se revoca toda la familia
+	if stored.IsUsed() {
+		return nil, uc.revokeFamily(ctx, stored.FamilyID, now)
+	}
+
+	if stored.IsExpired(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Marcar el token como usado y emitir el nuevo par en una transacción: si la emisión
+	// falla el token sigue sin usar y el cliente puede reintentar sin parecer un robo
+	var pair *TokenPair
+	reused := false
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Si otra petición se adelantó también es reutilización
+		marked, err := uc.refreshTokenRepository.MarkUsed(ctx, stored.ID, now)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			reused = true
+			return nil
+		}
+
+		user, err := uc.userRepository.GetByID(ctx, stored.UserID)
+		if errors.Is(err, model.ErrUserNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+
+		// Emitir un nuevo par dentro de la misma familia
+		pair, err = uc.tokenIssuer.Issue(ctx, user, stored.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// La familia se revoca fuera de la transacción para que la revocación no se deshaga
+	if reused {
+		return nil, uc.revokeFamily(ctx, stored.FamilyID, now)
+	}
+	return pair, nil
+}
+
+func (uc *RefreshTokenUseCase) revokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	if err := uc.refreshTokenRepository.RevokeFamily(ctx, familyID, now); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
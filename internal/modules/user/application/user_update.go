@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	sharedport "go-hexagonal-template/internal/modules/shared/domain/port"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UpdateUserInput reemplaza los datos del usuario; la contraseña solo cambia si se envía
+type UpdateUserInput struct {
+	Email    string `json:"email" binding:"required,email"`
+	Name     string `json:"name" binding:"required,min=1"`
+	Password string `json:"password" binding:"omitempty,min=6"`
+}
+
+// PatchUserInput modifica solo los campos enviados
+type PatchUserInput struct {
+	Email    *string `json:"email" binding:"omitempty,email"`
+	Name     *string `json:"name" binding:"omitempty,min=1"`
+	Password *string `json:"password" binding:"omitempty,min=6"`
+}
+
+type UpdateUserUseCase struct {
+	patchUserUseCase *PatchUserUseCase
+}
+
+func NewUpdateUserUseCase(userRepository port.UserRepository, policy sharedport.Policy, txManager sharedport.TxManager, outbox sharedport.EventOutbox) *UpdateUserUseCase {
+	return &UpdateUserUseCase{
+		patchUserUseCase: NewPatchUserUseCase(userRepository, policy, txManager, outbox),
+	}
+}
+
+func (uc *UpdateUserUseCase) Execute(ctx context.Context, principal *sharedmodel.Principal, id uint, input UpdateUserInput) (*model.User, error) {
+	patch := PatchUserInput{
+		Email: &input.Email,
+		Name:  &input.Name,
+	}
+	if input.Password != "" {
+		patch.Password = &input.Password
+	}
+	return uc.patchUserUseCase.Execute(ctx, principal, id, patch)
+}
+
+type PatchUserUseCase struct {
+	userRepository port.UserRepository
+	policy         sharedport.Policy
+	txManager      sharedport.TxManager
+	outbox         sharedport.EventOutbox
+}
+
+func NewPatchUserUseCase(userRepository port.UserRepository, policy sharedport.Policy, txManager sharedport.TxManager, outbox sharedport.EventOutbox) *PatchUserUseCase {
+	return &PatchUserUseCase{
+		userRepository: userRepository,
+		policy:         policy,
+		txManager:      txManager,
+		outbox:         outbox,
+	}
+}
+
+func (uc *PatchUserUseCase) Execute(ctx context.Context, principal *sharedmodel.Principal, id uint, input PatchUserInput) (*model.User, error) {
+	if err := uc.policy.Authorize(principal, sharedmodel.ActionUpdate, userResource(id)); err != nil {
+		return nil, err
+	}
+
+	// Hashear antes de abrir la transacción para no alargarla
+	var hashedPassword []byte
+	if input.Password != nil {
+		var err error
+		if hashedPassword, err = bcrypt.GenerateFromPassword([]byte(*input.Password), bcrypt.DefaultCost); err != nil {
+			return nil, err
+		}
+	}
+
+	// El usuario se lee dentro de la transacción, del primario: leído de una réplica con
+	// retraso, Update sobrescribiría los cambios que aún no se han replicado. El cambio de
+	// contraseña se registra como evento en la misma transacción.
+	var updated *model.User
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepository.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if input.Email != nil {
+			email := model.NormalizeEmail(*input.Email)
+			if email != user.Email {
+				if err := ensureEmailAvailable(ctx, uc.userRepository, email, user.ID); err != nil {
+					return err
+				}
+			}
+			user.Email = email
+		}
+		if input.Name != nil {
+			user.Name = *input.Name
+		}
+		if input.Password != nil {
+			user.Password = string(hashedPassword)
+		}
+		user.UpdatedAt = time.Now()
+
+		if updated, err = uc.userRepository.Update(ctx, user); err != nil {
+			return err
+		}
+		if input.Password == nil {
+			return nil
+		}
+		return uc.outbox.Add(ctx, model.PasswordChanged{UserID: user.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
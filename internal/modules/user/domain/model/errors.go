@@ -0,0 +1,12 @@
+package model
+
+import "go-hexagonal-template/internal/modules/shared/domain/apperror"
+
+var (
+	// ErrUserNotFound se retorna cuando el usuario no existe o está eliminado
+	ErrUserNotFound error = apperror.NotFound("USER_NOT_FOUND", "usuario no encontrado")
+	// ErrEmailTaken se retorna cuando otro usuario ya tiene registrado el email
+	ErrEmailTaken error = apperror.Conflict("EMAIL_TAKEN", "el email ya está registrado")
+	// ErrRefreshTokenNotFound se retorna cuando ningún refresh token tiene el hash buscado
+	ErrRefreshTokenNotFound error = apperror.NotFound("REFRESH_TOKEN_NOT_FOUND", "refresh token no encontrado")
+)
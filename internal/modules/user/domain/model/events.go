@@ -0,0 +1,31 @@
+package model
+
+// Nombres de los eventos que publica el módulo de usuarios
+const (
+	EventUserRegistered  = "user.registered"
+	EventUserLoggedIn    = "user.logged_in"
+	EventPasswordChanged = "user.password_changed"
+)
+
+// UserRegistered se emite al darse de alta un usuario
+type UserRegistered struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+func (UserRegistered) EventName() string { return EventUserRegistered }
+
+// UserLoggedIn se emite al iniciar sesión; SessionID es la familia de refresh tokens creada
+type UserLoggedIn struct {
+	UserID    uint   `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (UserLoggedIn) EventName() string { return EventUserLoggedIn }
+
+// PasswordChanged se emite cuando el usuario o un administrador cambia la contraseña
+type PasswordChanged struct {
+	UserID uint `json:"user_id"`
+}
+
+func (PasswordChanged) EventName() string { return EventPasswordChanged }
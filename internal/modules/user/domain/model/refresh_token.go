@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// RefreshToken representa un refresh token emitido a un usuario.
+// Los tokens de una misma sesión comparten FamilyID para poder revocarlos juntos.
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint       `json:"user_id" gorm:"index;not null"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex;not null"`
+	FamilyID  string     `json:"family_id" gorm:"index;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsExpired indica si el token ya venció
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsUsed indica si el token ya fue intercambiado por uno nuevo
+func (t *RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsRevoked indica si el token fue revocado
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
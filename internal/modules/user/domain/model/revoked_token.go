@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// RevokedToken representa un token de acceso revocado antes de su vencimiento
+type RevokedToken struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	JTI       string    `json:"jti" gorm:"column:jti;uniqueIndex;not null"`
+	UserID    uint      `json:"user_id" gorm:"index;not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserTokenRevocation registra el instante a partir del cual se invalidan
+// todos los tokens emitidos previamente para un usuario
+type UserTokenRevocation struct {
+	UserID        uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	RevokedBefore time.Time `json:"revoked_before"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// Roles predefinidos del sistema
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Permisos predefinidos del sistema
+const (
+	PermissionUsersRead   = "users:read"
+	PermissionUsersWrite  = "users:write"
+	PermissionUsersDelete = "users:delete"
+)
+
+// Permission representa una acción que puede concederse a un rol
+type Permission struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"uniqueIndex;not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Role agrupa permisos que se asignan a los usuarios
+type Role struct {
+	ID          uint         `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string       `json:"name" gorm:"uniqueIndex;not null"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions,omitempty" gorm:"many2many:role_permissions;"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// PermissionNames retorna los nombres de los permisos del rol
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		names = append(names, permission.Name)
+	}
+	return names
+}
+
+// DefaultRoles retorna los roles que deben existir en todo despliegue
+func DefaultRoles() []Role {
+	return []Role{
+		{
+			Name:        RoleAdmin,
+			Description: "Administrador con acceso completo",
+			Permissions: []Permission{
+				{Name: PermissionUsersRead, Description: "Consultar cualquier usuario"},
+				{Name: PermissionUsersWrite, Description: "Modificar cualquier usuario"},
+				{Name: PermissionUsersDelete, Description: "Eliminar cualquier usuario"},
+			},
+		},
+		{
+			Name:        RoleUser,
+			Description: "Usuario registrado",
+		},
+	}
+}
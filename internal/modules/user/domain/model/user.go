@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -21,6 +22,9 @@ type User struct {
 	// @Description Contraseña del usuario (hasheada)
 	Password string `json:"-" binding:"required"`
 
+	// @Description Roles asignados al usuario
+	Roles []Role `json:"roles,omitempty" gorm:"many2many:user_roles;"`
+
 	// @Description Fecha de creación del usuario
 	CreatedAt time.Time `json:"created_at"`
 
@@ -30,3 +34,8 @@ type User struct {
 	// @Description Fecha de eliminación del usuario (soft delete)
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
+
+// NormalizeEmail normaliza el email para que la unicidad no distinga mayúsculas ni espacios
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+)
+
+// UserSortFields son los campos por los que se puede ordenar el listado de usuarios
+var UserSortFields = []string{"id", "name", "email", "created_at", "updated_at"}
+
+// UserFilter restringe el listado de usuarios. Email y Name buscan subcadenas sin distinguir mayúsculas.
+type UserFilter struct {
+	Email          string
+	Name           string
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	IncludeDeleted bool
+}
+
+// UserQuery describe un listado de usuarios filtrado, ordenado y paginado
+type UserQuery struct {
+	Filter UserFilter
+	Sort   sharedmodel.Sort
+	Page   sharedmodel.PageRequest
+}
+
+// Normalize aplica los valores por defecto y valida la consulta
+func (q *UserQuery) Normalize() error {
+	if q.Sort.Field == "" {
+		q.Sort = sharedmodel.Sort{Field: "id"}
+	}
+	if err := q.Sort.Validate(UserSortFields); err != nil {
+		return err
+	}
+	if q.Filter.CreatedAfter != nil && q.Filter.CreatedBefore != nil && !q.Filter.CreatedAfter.Before(*q.Filter.CreatedBefore) {
+		return fmt.Errorf("%w: created_after debe ser anterior a created_before", sharedmodel.ErrInvalidQuery)
+	}
+	return q.Page.Normalize()
+}
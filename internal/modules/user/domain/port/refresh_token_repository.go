@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"go-hexagonal-template/internal/modules/user/domain/model"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *model.RefreshToken) (*model.RefreshToken, error)
+	// GetByHash retorna model.ErrRefreshTokenNotFound si ningún token tiene el hash
+	GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error)
+	// MarkUsed marca el token como usado y retorna false si ya lo estaba
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeAllForUser(ctx context.Context, userID uint, revokedAt time.Time) error
+}
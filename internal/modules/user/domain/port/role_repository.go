@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"go-hexagonal-template/internal/modules/user/domain/model"
+)
+
+type RoleRepository interface {
+	// Save crea el rol o reemplaza sus permisos si ya existe
+	Save(ctx context.Context, role *model.Role) (*model.Role, error)
+	GetByName(ctx context.Context, name string) (*model.Role, error)
+	GetByUserID(ctx context.Context, userID uint) ([]model.Role, error)
+	AssignToUser(ctx context.Context, userID uint, roleName string) error
+}
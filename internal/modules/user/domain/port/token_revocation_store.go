@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+type TokenRevocationStore interface {
+	// Revoke invalida un token de acceso concreto hasta su vencimiento
+	Revoke(ctx context.Context, jti string, userID uint, expiresAt time.Time) error
+	// RevokeAllForUser invalida todos los tokens del usuario emitidos hasta before. Como el iat
+	// de los tokens tiene precisión de segundos, también se invalidan los emitidos en el mismo
+	// segundo que before, aunque sea justo después: es preferible a que uno anterior sobreviva.
+	RevokeAllForUser(ctx context.Context, userID uint, before time.Time) error
+	IsRevoked(ctx context.Context, jti string, userID uint, issuedAt time.Time) (bool, error)
+	// PurgeExpired elimina las revocaciones de tokens ya vencidos y retorna cuántas eliminó
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -1,9 +1,29 @@
 package port
 
-import "go-hexagonal-template/internal/modules/user/domain/model"
+import (
+	"context"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+)
 
 type UserRepository interface {
-	Create(user *model.User) (*model.User, error)
-	GetByID(id uint) (*model.User, error)
-	GetByEmail(email string) (*model.User, error)
+	Create(ctx context.Context, user *model.User) (*model.User, error)
+	// GetByID puede devolver datos con retraso de replicación si se llama fuera de una
+	// transacción; los flujos de lectura-modificación-escritura deben llamarlo dentro de ella
+	GetByID(ctx context.Context, id uint) (*model.User, error)
+	// GetByEmail lee siempre del primario
+	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	// GetByEmailIncludingDeleted es como GetByEmail, pero también encuentra usuarios
+	// eliminados de forma lógica, cuyo email sigue ocupado
+	GetByEmailIncludingDeleted(ctx context.Context, email string) (*model.User, error)
+	// List retorna una página de usuarios según una consulta ya normalizada
+	List(ctx context.Context, query model.UserQuery) (*sharedmodel.Page[model.User], error)
+	Update(ctx context.Context, user *model.User) (*model.User, error)
+	// Delete elimina el usuario de forma lógica (soft delete)
+	Delete(ctx context.Context, id uint) error
+	// Restore recupera un usuario eliminado de forma lógica
+	Restore(ctx context.Context, id uint) (*model.User, error)
+	// HardDelete elimina el usuario definitivamente junto con sus roles asignados
+	HardDelete(ctx context.Context, id uint) error
 }
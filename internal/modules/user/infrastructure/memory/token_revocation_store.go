@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-hexagonal-template/internal/modules/user/domain/port"
+)
+
+// TokenRevocationStore implementa la interfaz TokenRevocationStore en memoria.
+// Es adecuado para una sola instancia o para testing; las revocaciones se pierden al reiniciar.
+type TokenRevocationStore struct {
+	mu            sync.RWMutex
+	revoked       map[string]time.Time
+	revokedBefore map[uint]time.Time
+	now           func() time.Time
+}
+
+// NewTokenRevocationStore crea una nueva instancia de TokenRevocationStore
+func NewTokenRevocationStore() port.TokenRevocationStore {
+	return &TokenRevocationStore{
+		revoked:       make(map[string]time.Time),
+		revokedBefore: make(map[uint]time.Time),
+		now:           time.Now,
+	}
+}
+
+// Revoke implementa el método Revoke de la interfaz TokenRevocationStore
+func (s *TokenRevocationStore) Revoke(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Aprovechar la escritura para descartar revocaciones ya vencidas
+	s.purge(s.now())
+	s.revoked[jti] = expiresAt
+	return nil
+}
+
+// RevokeAllForUser implementa el método RevokeAllForUser de la interfaz TokenRevocationStore
+func (s *TokenRevocationStore) RevokeAllForUser(ctx context.Context, userID uint, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revokedBefore[userID] = before.Truncate(time.Second)
+	return nil
+}
+
+// IsRevoked implementa el método IsRevoked de la interfaz TokenRevocationStore
+func (s *TokenRevocationStore) IsRevoked(ctx context.Context, jti string, userID uint, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.revoked[jti]; ok {
+		return true, nil
+	}
+	if before, ok := s.revokedBefore[userID]; ok && !issuedAt.After(before) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// PurgeExpired implementa el método PurgeExpired de la interfaz TokenRevocationStore
+func (s *TokenRevocationStore) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.purge(now), nil
+}
+
+func (s *TokenRevocationStore) purge(now time.Time) int64 {
+	var purged int64
+	for id, exp := range s.revoked {
+		if exp.Before(now) {
+			delete(s.revoked, id)
+			purged++
+		}
+	}
+	return purged
+}
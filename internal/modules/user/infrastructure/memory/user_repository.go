@@ -0,0 +1,333 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"gorm.io/gorm"
+)
+
+// UserRepository implementa la interfaz UserRepository en memoria con la misma semántica
+// que el repositorio GORM: IDs autoincrementales, email único también frente a usuarios
+// eliminados de forma lógica y búsqueda por email sin distinguir mayúsculas.
+// Es adecuado para testing; los usuarios se pierden al reiniciar.
+type UserRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]model.User
+	lastID uint
+	now    func() time.Time
+}
+
+// NewUserRepository crea una nueva instancia de UserRepository
+func NewUserRepository() port.UserRepository {
+	return &UserRepository{
+		users: make(map[uint]model.User),
+		now:   time.Now,
+	}
+}
+
+// userSortKey compara usuarios por un campo de orden y lo serializa en los cursores
+type userSortKey struct {
+	compare func(a, b model.User) int
+	value   func(user model.User) string
+	// set copia en user el valor leído de un cursor
+	set func(user *model.User, value string) error
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func setTime(target *time.Time, value string) error {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return err
+	}
+	*target = parsed
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// userSortKeys debe cubrir model.UserSortFields
+var userSortKeys = map[string]userSortKey{
+	"id": {
+		compare: func(a, b model.User) int { return 0 },
+		value:   func(user model.User) string { return strconv.FormatUint(uint64(user.ID), 10) },
+		set:     func(user *model.User, value string) error { return nil },
+	},
+	"name": {
+		compare: func(a, b model.User) int { return strings.Compare(a.Name, b.Name) },
+		value:   func(user model.User) string { return user.Name },
+		set:     func(user *model.User, value string) error { user.Name = value; return nil },
+	},
+	"email": {
+		compare: func(a, b model.User) int { return strings.Compare(a.Email, b.Email) },
+		value:   func(user model.User) string { return user.Email },
+		set:     func(user *model.User, value string) error { user.Email = value; return nil },
+	},
+	"created_at": {
+		compare: func(a, b model.User) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+		value:   func(user model.User) string { return formatTime(user.CreatedAt) },
+		set:     func(user *model.User, value string) error { return setTime(&user.CreatedAt, value) },
+	},
+	"updated_at": {
+		compare: func(a, b model.User) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+		value:   func(user model.User) string { return formatTime(user.UpdatedAt) },
+		set:     func(user *model.User, value string) error { return setTime(&user.UpdatedAt, value) },
+	},
+}
+
+// Create implementa el método Create de la interfaz UserRepository
+func (r *UserRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.emailTaken(user.Email, 0) {
+		return nil, model.ErrEmailTaken
+	}
+	if user.ID == 0 {
+		user.ID = r.lastID + 1
+	} else if _, ok := r.users[user.ID]; ok {
+		return nil, gorm.ErrDuplicatedKey
+	}
+	if user.ID > r.lastID {
+		r.lastID = user.ID
+	}
+
+	now := r.now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+	r.store(*user)
+	return user, nil
+}
+
+// GetByID implementa el método GetByID de la interfaz UserRepository
+func (r *UserRepository) GetByID(ctx context.Context, id uint) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, model.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+// GetByEmail implementa el método GetByEmail de la interfaz UserRepository
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Igual que en la base de datos, se normaliza el email buscado y se compara con el guardado
+	email = model.NormalizeEmail(email)
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, model.ErrUserNotFound
+}
+
+// GetByEmailIncludingDeleted implementa el método GetByEmailIncludingDeleted de la interfaz UserRepository
+func (r *UserRepository) GetByEmailIncludingDeleted(ctx context.Context, email string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	email = model.NormalizeEmail(email)
+	for _, user := range r.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, model.ErrUserNotFound
+}
+
+// List implementa el método List de la interfaz UserRepository
+func (r *UserRepository) List(ctx context.Context, query model.UserQuery) (*sharedmodel.Page[model.User], error) {
+	key, ok := userSortKeys[query.Sort.Field]
+	if !ok {
+		return nil, sharedmodel.ErrInvalidQuery
+	}
+	// Orden total: la columna pedida y, en caso de empate, el id
+	compare := func(a, b model.User) int {
+		result := key.compare(a, b)
+		if result == 0 && a.ID != b.ID {
+			result = -1
+			if a.ID > b.ID {
+				result = 1
+			}
+		}
+		if query.Sort.Descending {
+			return -result
+		}
+		return result
+	}
+
+	r.mu.RLock()
+	users := make([]model.User, 0, len(r.users))
+	for _, user := range r.users {
+		if matchesUserFilter(user, query.Filter) {
+			users = append(users, user)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(users, func(i, j int) bool {
+		return compare(users[i], users[j]) < 0
+	})
+
+	page := &sharedmodel.Page[model.User]{Limit: query.Page.Limit}
+	if query.Page.Cursor != "" {
+		cursor, err := sharedmodel.DecodeCursor(query.Page.Cursor, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		after := model.User{ID: cursor.ID}
+		if err := key.set(&after, cursor.Value); err != nil {
+			return nil, sharedmodel.ErrInvalidCursor
+		}
+		start := sort.Search(len(users), func(i int) bool {
+			return compare(users[i], after) > 0
+		})
+		users = users[start:]
+	} else {
+		total := int64(len(users))
+		page.Total = &total
+		if query.Page.Offset < len(users) {
+			users = users[query.Page.Offset:]
+		} else {
+			users = users[:0]
+		}
+	}
+
+	if len(users) > query.Page.Limit {
+		users = users[:query.Page.Limit]
+		last := users[len(users)-1]
+		page.NextCursor = sharedmodel.Cursor{
+			Sort:  query.Sort.String(),
+			Value: key.value(last),
+			ID:    last.ID,
+		}.Encode()
+	}
+	page.Items = users
+	return page, nil
+}
+
+// Update implementa el método Update de la interfaz UserRepository
+func (r *UserRepository) Update(ctx context.Context, user *model.User) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.users[user.ID]
+	if !ok || current.DeletedAt.Valid {
+		return nil, model.ErrUserNotFound
+	}
+	if r.emailTaken(user.Email, user.ID) {
+		return nil, model.ErrEmailTaken
+	}
+
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = current.CreatedAt
+	}
+	user.UpdatedAt = r.now()
+	r.store(*user)
+	return user, nil
+}
+
+// Delete implementa el método Delete de la interfaz UserRepository
+func (r *UserRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return model.ErrUserNotFound
+	}
+	user.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.users[id] = user
+	return nil
+}
+
+// Restore implementa el método Restore de la interfaz UserRepository
+func (r *UserRepository) Restore(ctx context.Context, id uint) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || !user.DeletedAt.Valid {
+		return nil, model.ErrUserNotFound
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	user.UpdatedAt = r.now()
+	r.users[id] = user
+	return &user, nil
+}
+
+// HardDelete implementa el método HardDelete de la interfaz UserRepository
+func (r *UserRepository) HardDelete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return model.ErrUserNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
+
+// emailTaken indica si otro usuario, aunque esté eliminado de forma lógica, tiene el email.
+// Como el índice único de la base de datos, distingue mayúsculas.
+func (r *UserRepository) emailTaken(email string, exceptID uint) bool {
+	for id, user := range r.users {
+		if id != exceptID && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// store guarda una copia sin roles: como en la base de datos, las consultas no los cargan
+func (r *UserRepository) store(user model.User) {
+	user.Roles = nil
+	r.users[user.ID] = user
+}
+
+func matchesUserFilter(user model.User, filter model.UserFilter) bool {
+	if user.DeletedAt.Valid && !filter.IncludeDeleted {
+		return false
+	}
+	if filter.Email != "" && !strings.Contains(strings.ToLower(user.Email), strings.ToLower(filter.Email)) {
+		return false
+	}
+	if filter.Name != "" && !strings.Contains(strings.ToLower(user.Name), strings.ToLower(filter.Name)) {
+		return false
+	}
+	if filter.CreatedAfter != nil && !user.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !user.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+-- Esquema inicial para MySQL. Las columnas indexadas son VARCHAR porque MySQL no indexa
+-- TEXT sin longitud de prefijo; las fechas usan DATETIME(6) en UTC.
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id          BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at  DATETIME(6),
+    UNIQUE INDEX idx_permissions_name (name)
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id          BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at  DATETIME(6),
+    updated_at  DATETIME(6),
+    UNIQUE INDEX idx_roles_name (name)
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id       BIGINT UNSIGNED NOT NULL,
+    permission_id BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id),
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id         BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name       VARCHAR(255),
+    email      VARCHAR(255),
+    password   VARCHAR(255),
+    created_at DATETIME(6),
+    updated_at DATETIME(6),
+    deleted_at DATETIME(6),
+    CONSTRAINT uni_users_email UNIQUE (email),
+    INDEX idx_users_deleted_at (deleted_at)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id BIGINT UNSIGNED NOT NULL,
+    role_id BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id    BIGINT UNSIGNED NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    family_id  VARCHAR(255) NOT NULL,
+    expires_at DATETIME(6),
+    used_at    DATETIME(6),
+    revoked_at DATETIME(6),
+    created_at DATETIME(6),
+    INDEX idx_refresh_tokens_user_id (user_id),
+    UNIQUE INDEX idx_refresh_tokens_token_hash (token_hash),
+    INDEX idx_refresh_tokens_family_id (family_id)
+);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    id         BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    jti        VARCHAR(255) NOT NULL,
+    user_id    BIGINT UNSIGNED NOT NULL,
+    expires_at DATETIME(6),
+    created_at DATETIME(6),
+    UNIQUE INDEX idx_revoked_tokens_jti (jti),
+    INDEX idx_revoked_tokens_user_id (user_id),
+    INDEX idx_revoked_tokens_expires_at (expires_at)
+);
+
+CREATE TABLE IF NOT EXISTS user_token_revocations (
+    user_id        BIGINT UNSIGNED PRIMARY KEY,
+    revoked_before DATETIME(6),
+    updated_at     DATETIME(6)
+);
@@ -0,0 +1,2 @@
+-- Las mayúsculas originales no se conservan: revertir no cambia los datos
+SELECT 1;
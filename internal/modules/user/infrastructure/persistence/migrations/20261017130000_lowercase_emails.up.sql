@@ -0,0 +1,5 @@
+-- Normaliza los emails registrados antes de guardarlos en minúsculas para que las búsquedas
+-- comparen la columna tal cual y usen su índice único. Si dos usuarios solo se distinguen por
+-- mayúsculas la restricción uni_users_email hace fallar la migración: hay que fusionarlos antes.
+
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
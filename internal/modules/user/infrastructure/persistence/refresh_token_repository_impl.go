@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepositoryImpl implementa la interfaz RefreshTokenRepository
+type RefreshTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepositoryImpl crea una nueva instancia de RefreshTokenRepositoryImpl
+func NewRefreshTokenRepositoryImpl(db *gorm.DB) port.RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create implementa el método Create de la interfaz RefreshTokenRepository
+func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *model.RefreshToken) (*model.RefreshToken, error) {
+	result := transaction.DB(ctx, r.db).Create(token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return token, nil
+}
+
+// GetByHash implementa el método GetByHash de la interfaz RefreshTokenRepository
+func (r *RefreshTokenRepositoryImpl) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	result := transaction.DB(ctx, r.db).First(&token, "token_hash = ?", hash)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, model.ErrRefreshTokenNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// MarkUsed implementa el método MarkUsed de la interfaz RefreshTokenRepository.
+// La condición sobre used_at hace que solo una petición concurrente pueda rotar el token.
+func (r *RefreshTokenRepositoryImpl) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).Model(&model.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily implementa el método RevokeFamily de la interfaz RefreshTokenRepository
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+// RevokeAllForUser implementa el método RevokeAllForUser de la interfaz RefreshTokenRepository
+func (r *RefreshTokenRepositoryImpl) RevokeAllForUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&model.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
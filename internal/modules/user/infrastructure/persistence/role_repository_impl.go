@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"gorm.io/gorm"
+)
+
+// RoleRepositoryImpl implementa la interfaz RoleRepository
+type RoleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRoleRepositoryImpl crea una nueva instancia de RoleRepositoryImpl
+func NewRoleRepositoryImpl(db *gorm.DB) port.RoleRepository {
+	return &RoleRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save implementa el método Save de la interfaz RoleRepository
+func (r *RoleRepositoryImpl) Save(ctx context.Context, role *model.Role) (*model.Role, error) {
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Asegurar que cada permiso exista
+		permissions := make([]model.Permission, 0, len(role.Permissions))
+		for _, permission := range role.Permissions {
+			p := model.Permission{Name: permission.Name}
+			if err := tx.Where(p).Attrs(model.Permission{Description: permission.Description}).FirstOrCreate(&p).Error; err != nil {
+				return err
+			}
+			permissions = append(permissions, p)
+		}
+
+		// Crear el rol si no existe y reemplazar sus permisos
+		stored := model.Role{Name: role.Name}
+		if err := tx.Where(stored).Attrs(model.Role{Description: role.Description}).FirstOrCreate(&stored).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&stored).Association("Permissions").Replace(permissions); err != nil {
+			return err
+		}
+
+		stored.Permissions = permissions
+		*role = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// GetByName implementa el método GetByName de la interfaz RoleRepository
+func (r *RoleRepositoryImpl) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	var role model.Role
+	result := transaction.DB(ctx, r.db).Preload("Permissions").First(&role, "name = ?", name)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &role, nil
+}
+
+// GetByUserID implementa el método GetByUserID de la interfaz RoleRepository
+func (r *RoleRepositoryImpl) GetByUserID(ctx context.Context, userID uint) ([]model.Role, error) {
+	var roles []model.Role
+	result := transaction.DB(ctx, r.db).Preload("Permissions").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Find(&roles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return roles, nil
+}
+
+// AssignToUser implementa el método AssignToUser de la interfaz RoleRepository
+func (r *RoleRepositoryImpl) AssignToUser(ctx context.Context, userID uint, roleName string) error {
+	role, err := r.GetByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	return transaction.DB(ctx, r.db).Model(&model.User{ID: userID}).Omit("Roles.*").Association("Roles").Append(role)
+}
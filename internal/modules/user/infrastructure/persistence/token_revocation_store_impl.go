@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRevocationStoreImpl implementa la interfaz TokenRevocationStore sobre GORM
+type TokenRevocationStoreImpl struct {
+	db *gorm.DB
+}
+
+// NewTokenRevocationStoreImpl crea una nueva instancia de TokenRevocationStoreImpl
+func NewTokenRevocationStoreImpl(db *gorm.DB) port.TokenRevocationStore {
+	return &TokenRevocationStoreImpl{
+		db: db,
+	}
+}
+
+// Revoke implementa el método Revoke de la interfaz TokenRevocationStore
+func (s *TokenRevocationStoreImpl) Revoke(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	revoked := &model.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	return transaction.DB(ctx, s.db).Clauses(clause.OnConflict{DoNothing: true}).Create(revoked).Error
+}
+
+// RevokeAllForUser implementa el método RevokeAllForUser de la interfaz TokenRevocationStore
+func (s *TokenRevocationStoreImpl) RevokeAllForUser(ctx context.Context, userID uint, before time.Time) error {
+	revocation := &model.UserTokenRevocation{
+		UserID:        userID,
+		RevokedBefore: before.Truncate(time.Second),
+	}
+	return transaction.DB(ctx, s.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_before", "updated_at"}),
+	}).Create(revocation).Error
+}
+
+// IsRevoked implementa el método IsRevoked de la interfaz TokenRevocationStore
+func (s *TokenRevocationStoreImpl) IsRevoked(ctx context.Context, jti string, userID uint, issuedAt time.Time) (bool, error) {
+	var count int64
+	if err := transaction.DB(ctx, s.db).Model(&model.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	var revocation model.UserTokenRevocation
+	err := transaction.DB(ctx, s.db).First(&revocation, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !issuedAt.After(revocation.RevokedBefore), nil
+}
+
+// PurgeExpired implementa el método PurgeExpired de la interfaz TokenRevocationStore
+func (s *TokenRevocationStoreImpl) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := transaction.DB(ctx, s.db).Where("expires_at < ?", now).Delete(&model.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
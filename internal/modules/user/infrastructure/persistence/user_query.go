@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+
+	"gorm.io/gorm"
+)
+
+// sortColumn traduce un campo de orden a su columna y sabe serializar su valor en un cursor
+type sortColumn struct {
+	name  string
+	value func(user model.User) string
+	parse func(value string) (interface{}, error)
+}
+
+func parseString(value string) (interface{}, error) {
+	return value, nil
+}
+
+func parseTime(value string) (interface{}, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// userSortColumns debe cubrir model.UserSortFields
+var userSortColumns = map[string]sortColumn{
+	"id": {
+		name:  "id",
+		value: func(user model.User) string { return strconv.FormatUint(uint64(user.ID), 10) },
+		parse: func(value string) (interface{}, error) { return strconv.ParseUint(value, 10, 64) },
+	},
+	"name": {
+		name:  "name",
+		value: func(user model.User) string { return user.Name },
+		parse: parseString,
+	},
+	"email": {
+		name:  "email",
+		value: func(user model.User) string { return user.Email },
+		parse: parseString,
+	},
+	"created_at": {
+		name:  "created_at",
+		value: func(user model.User) string { return formatTime(user.CreatedAt) },
+		parse: parseTime,
+	},
+	"updated_at": {
+		name:  "updated_at",
+		value: func(user model.User) string { return formatTime(user.UpdatedAt) },
+		parse: parseTime,
+	},
+}
+
+// likeEscaper escapa los comodines de LIKE usando '!', que no requiere tratamiento especial en ningún motor
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func containsPattern(value string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(value)) + "%"
+}
+
+func applyUserFilter(tx *gorm.DB, filter model.UserFilter) *gorm.DB {
+	if filter.Email != "" {
+		tx = tx.Where("LOWER(email) LIKE ? ESCAPE '!'", containsPattern(filter.Email))
+	}
+	if filter.Name != "" {
+		tx = tx.Where("LOWER(name) LIKE ? ESCAPE '!'", containsPattern(filter.Name))
+	}
+	if filter.CreatedAfter != nil {
+		tx = tx.Where("created_at > ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		tx = tx.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return tx
+}
+
+// orderClause ordena por la columna pedida y desempata por id para que el orden sea total
+func orderClause(column sortColumn, descending bool) string {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+	if column.name == "id" {
+		return "id " + direction
+	}
+	return column.name + " " + direction + ", id " + direction
+}
+
+// keysetCondition construye la condición que selecciona los elementos posteriores al cursor
+func keysetCondition(column sortColumn, descending bool, cursor sharedmodel.Cursor) (string, []interface{}, error) {
+	operator := ">"
+	if descending {
+		operator = "<"
+	}
+	if column.name == "id" {
+		return "id " + operator + " ?", []interface{}{cursor.ID}, nil
+	}
+
+	value, err := column.parse(cursor.Value)
+	if err != nil {
+		return "", nil, sharedmodel.ErrInvalidCursor
+	}
+	condition := "(" + column.name + " " + operator + " ? OR (" + column.name + " = ? AND id " + operator + " ?))"
+	return condition, []interface{}{value, value, cursor.ID}, nil
+}
@@ -1,16 +1,49 @@
 package persistence
 
 import (
+	"context"
+	"errors"
+
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/port"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// Códigos de violación de unicidad de cada motor
+const (
+	// pgUniqueViolation es el código SQLSTATE de Postgres
+	pgUniqueViolation = "23505"
+	// mysqlDuplicateEntry es ER_DUP_ENTRY
+	mysqlDuplicateEntry = 1062
+	// sqliteConstraintUnique es el código extendido SQLITE_CONSTRAINT_UNIQUE
+	sqliteConstraintUnique = 2067
+)
+
+// sqliteError es el error del driver SQLite; se reconoce por su método Code para no
+// depender del paquete del driver
+type sqliteError interface {
+	error
+	Code() int
+}
+
 // DBInterface define la interfaz para las operaciones de base de datos
 type DBInterface interface {
+	// WithContext devuelve una sesión cuyas consultas se cancelan junto con ctx
+	WithContext(ctx context.Context) DBInterface
+	// ForRead es como WithContext, pero puede leer de una réplica con retraso de replicación
+	ForRead(ctx context.Context) DBInterface
 	Create(value interface{}) *gorm.DB
 	First(dest interface{}, conds ...interface{}) *gorm.DB
+	Find(dest interface{}, conds ...interface{}) *gorm.DB
+	Save(value interface{}) *gorm.DB
+	Delete(value interface{}, conds ...interface{}) *gorm.DB
+	Unscoped() *gorm.DB
+	Model(value interface{}) *gorm.DB
 }
 
 // UserRepositoryImpl implementa la interfaz UserRepository
@@ -18,38 +51,220 @@ type UserRepositoryImpl struct {
 	db DBInterface
 }
 
+// gormDB adapta *gorm.DB a DBInterface
+type gormDB struct {
+	*gorm.DB
+}
+
+func (db gormDB) WithContext(ctx context.Context) DBInterface {
+	return gormDB{transaction.DB(ctx, db.DB)}
+}
+
+func (db gormDB) ForRead(ctx context.Context) DBInterface {
+	return gormDB{transaction.ReadDB(ctx, db.DB)}
+}
+
 // NewUserRepositoryImpl crea una nueva instancia de UserRepositoryImpl
-func NewUserRepositoryImpl(db DBInterface) port.UserRepository {
+func NewUserRepositoryImpl(db *gorm.DB) port.UserRepository {
+	return NewUserRepositoryWithDB(gormDB{db})
+}
+
+// NewUserRepositoryWithDB crea el repositorio sobre cualquier DBInterface, por ejemplo un mock
+func NewUserRepositoryWithDB(db DBInterface) port.UserRepository {
 	return &UserRepositoryImpl{
 		db: db,
 	}
 }
 
 // Create implementa el método Create de la interfaz UserRepository
-func (r *UserRepositoryImpl) Create(user *model.User) (*model.User, error) {
-	result := r.db.Create(user)
+func (r *UserRepositoryImpl) Create(ctx context.Context, user *model.User) (*model.User, error) {
+	result := r.db.WithContext(ctx).Create(user)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, translateWriteError(result.Error)
 	}
 	return user, nil
 }
 
-// GetByID implementa el método GetByID de la interfaz UserRepository
-func (r *UserRepositoryImpl) GetByID(id uint) (*model.User, error) {
+// GetByID implementa el método GetByID de la interfaz UserRepository. Fuera de una transacción
+// puede leer de una réplica; quien vaya a modificar el usuario debe leerlo dentro de la transacción.
+func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uint) (*model.User, error) {
 	var user model.User
-	result := r.db.First(&user, "id = ?", id)
+	result := r.db.ForRead(ctx).First(&user, "id = ?", id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, translateNotFound(result.Error)
 	}
 	return &user, nil
 }
 
 // GetByEmail implementa el método GetByEmail de la interfaz UserRepository
-func (r *UserRepositoryImpl) GetByEmail(email string) (*model.User, error) {
+func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	// Se lee del primario: el login, la comprobación de email libre y el seed no pueden ver
+	// una contraseña o un alta con retraso de replicación.
+	// Los emails se guardan normalizados (la migración lowercase_emails corrige los antiguos),
+	// así que basta comparar la columna y se aprovecha su índice único
+	result := r.db.WithContext(ctx).First(&user, "email = ?", model.NormalizeEmail(email))
+	if result.Error != nil {
+		return nil, translateNotFound(result.Error)
+	}
+	return &user, nil
+}
+
+// GetByEmailIncludingDeleted implementa el método GetByEmailIncludingDeleted de la interfaz UserRepository
+func (r *UserRepositoryImpl) GetByEmailIncludingDeleted(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	result := r.db.First(&user, "email = ?", email)
+	result := r.db.WithContext(ctx).Unscoped().First(&user, "email = ?", model.NormalizeEmail(email))
+	if result.Error != nil {
+		return nil, translateNotFound(result.Error)
+	}
+	return &user, nil
+}
+
+// List implementa el método List de la interfaz UserRepository
+func (r *UserRepositoryImpl) List(ctx context.Context, query model.UserQuery) (*sharedmodel.Page[model.User], error) {
+	column, ok := userSortColumns[query.Sort.Field]
+	if !ok {
+		return nil, sharedmodel.ErrInvalidQuery
+	}
+
+	tx := r.db.WithContext(ctx).Model(&model.User{})
+	if query.Filter.IncludeDeleted {
+		tx = r.db.WithContext(ctx).Unscoped().Model(&model.User{})
+	}
+	// La sesión permite reutilizar los filtros para el conteo y la búsqueda
+	tx = applyUserFilter(tx, query.Filter).Session(&gorm.Session{})
+
+	page := &sharedmodel.Page[model.User]{Limit: query.Page.Limit}
+
+	find := tx
+	if query.Page.Cursor != "" {
+		cursor, err := sharedmodel.DecodeCursor(query.Page.Cursor, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		condition, args, err := keysetCondition(column, query.Sort.Descending, cursor)
+		if err != nil {
+			return nil, err
+		}
+		find = find.Where(condition, args...)
+	} else {
+		var total int64
+		if err := tx.Count(&total).Error; err != nil {
+			return nil, err
+		}
+		page.Total = &total
+		find = find.Offset(query.Page.Offset)
+	}
+
+	// Se pide un elemento extra para saber si existe una página siguiente
+	var users []model.User
+	result := find.Order(orderClause(column, query.Sort.Descending)).Limit(query.Page.Limit + 1).Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(users) > query.Page.Limit {
+		users = users[:query.Page.Limit]
+		last := users[len(users)-1]
+		page.NextCursor = sharedmodel.Cursor{
+			Sort:  query.Sort.String(),
+			Value: column.value(last),
+			ID:    last.ID,
+		}.Encode()
+	}
+	if users == nil {
+		users = []model.User{}
+	}
+	page.Items = users
+
+	return page, nil
+}
+
+// Update implementa el método Update de la interfaz UserRepository
+func (r *UserRepositoryImpl) Update(ctx context.Context, user *model.User) (*model.User, error) {
+	result := r.db.WithContext(ctx).Save(user)
+	if result.Error != nil {
+		return nil, translateWriteError(result.Error)
+	}
+	return user, nil
+}
+
+// Delete implementa el método Delete de la interfaz UserRepository
+func (r *UserRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&model.User{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
+
+// Restore implementa el método Restore de la interfaz UserRepository
+func (r *UserRepositoryImpl) Restore(ctx context.Context, id uint) (*model.User, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&model.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	if result.RowsAffected == 0 {
+		return nil, model.ErrUserNotFound
+	}
+
+	// Se relee del primario: una réplica aún podría tener el usuario eliminado
+	var user model.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
+		return nil, translateNotFound(err)
+	}
 	return &user, nil
 }
+
+// HardDelete implementa el método HardDelete de la interfaz UserRepository
+func (r *UserRepositoryImpl) HardDelete(ctx context.Context, id uint) error {
+	// Seleccionar Roles elimina también las filas de user_roles
+	result := r.db.WithContext(ctx).Unscoped().Select("Roles").Delete(&model.User{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
+
+// translateNotFound convierte el error de registro inexistente de GORM en el error del dominio
+func translateNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.ErrUserNotFound
+	}
+	return err
+}
+
+// translateWriteError convierte las violaciones de unicidad del driver en errores del dominio.
+// El email es la única columna única de users además de la clave primaria.
+func translateWriteError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) || isUniqueViolation(err) {
+		return model.ErrEmailTaken
+	}
+	return err
+}
+
+// isUniqueViolation reconoce la violación de unicidad de Postgres, MySQL y SQLite
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+	var liteErr sqliteError
+	if errors.As(err, &liteErr) {
+		return liteErr.Code() == sqliteConstraintUnique
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Package user conecta el módulo de usuarios, roles y sesiones con el servidor
+package user
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"time"
+
+	"go-hexagonal-template/internal/handlers"
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/infrastructure/module"
+	"go-hexagonal-template/internal/infrastructure/outbox"
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	"go-hexagonal-template/internal/middleware"
+	healthport "go-hexagonal-template/internal/modules/health/domain/port"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/gin-gonic/gin"
+)
+
+// revocationPurgeInterval es la frecuencia con la que se eliminan las revocaciones vencidas
+const revocationPurgeInterval = time.Hour
+
+// Module registra usuarios, sesiones y claves de firma, y autentica las rutas protegidas
+type Module struct {
+	tokens          *auth.TokenManager
+	revocationStore port.TokenRevocationStore
+	userHandler     *handlers.UserHandler
+	authHandler     *handlers.AuthHandler
+	// cancel y done detienen la purga de revocaciones en segundo plano
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewModule() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return "user"
+}
+
+func (m *Module) Migrations() fs.FS {
+	return persistence.Migrations()
+}
+
+// Init conecta los repositorios y crea los roles por defecto y el administrador inicial
+func (m *Module) Init(ctx context.Context, deps module.Dependencies) error {
+	cfg := deps.Config
+	userRepo := persistence.NewUserRepositoryImpl(cfg.DB)
+	roleRepo := persistence.NewRoleRepositoryImpl(cfg.DB)
+	refreshTokenRepo := persistence.NewRefreshTokenRepositoryImpl(cfg.DB)
+	txManager := transaction.NewGormTxManager(cfg.DB, transaction.DefaultMaxAttempts)
+	eventOutbox := outbox.NewGormStore(cfg.DB)
+
+	m.tokens = cfg.Tokens
+	m.revocationStore = persistence.NewTokenRevocationStoreImpl(cfg.DB)
+	m.userHandler = handlers.NewUserHandler(userRepo, roleRepo, refreshTokenRepo, m.revocationStore, txManager, eventOutbox, cfg.Tokens)
+	m.authHandler = handlers.NewAuthHandler(cfg.Tokens.Keys())
+
+	seed, err := application.NewSeedRolesUseCase(userRepo, roleRepo, txManager).Execute(ctx, application.SeedRolesInput{
+		AdminEmail:    cfg.Admin.Email,
+		AdminName:     cfg.Admin.Name,
+		AdminPassword: cfg.Admin.Password,
+	})
+	if err != nil {
+		return err
+	}
+	if seed.AdminSkipped != nil {
+		log.Printf("ADVERTENCIA: administrador inicial %s: %v", cfg.Admin.Email, seed.AdminSkipped)
+	}
+
+	// La purga de revocaciones vencidas se detiene con el hook de apagado
+	runCtx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go func() {
+		defer close(m.done)
+		m.purgeRevocations(runCtx)
+	}()
+	return nil
+}
+
+// purgeRevocations elimina cada revocationPurgeInterval las revocaciones de tokens que ya
+// vencieron: un token vencido se rechaza igualmente y la fila solo ocupa espacio
+func (m *Module) purgeRevocations(ctx context.Context) {
+	ticker := time.NewTicker(revocationPurgeInterval)
+	defer ticker.Stop()
+	for {
+		purged, err := m.revocationStore.PurgeExpired(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Error eliminando revocaciones vencidas: %v", err)
+		} else if purged > 0 {
+			log.Printf("Revocaciones vencidas eliminadas: %d", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Module) Authenticate() gin.HandlerFunc {
+	return middleware.AuthMiddleware(m.tokens, m.revocationStore)
+}
+
+func (m *Module) RegisterRoutes(routes module.Routes) {
+	routes.Public.GET("/.well-known/jwks.json", m.authHandler.JWKS)
+	routes.Public.POST("/users", m.userHandler.CreateUser)
+	routes.Public.POST("/login", m.userHandler.Login)
+	routes.Public.POST("/token/refresh", m.userHandler.RefreshToken)
+
+	routes.Protected.GET("/users", middleware.RequirePermission(model.PermissionUsersRead), m.userHandler.ListUsers)
+	routes.Protected.GET("/users/:id", m.userHandler.GetUser)
+	routes.Protected.PUT("/users/:id", m.userHandler.UpdateUser)
+	routes.Protected.PATCH("/users/:id", m.userHandler.PatchUser)
+	routes.Protected.DELETE("/users/:id", m.userHandler.DeleteUser)
+	routes.Protected.POST("/logout", m.userHandler.Logout)
+	routes.Protected.POST("/logout-all", m.userHandler.LogoutAll)
+
+	admin := routes.Protected.Group("/admin", middleware.RequirePermission(model.PermissionUsersDelete))
+	admin.POST("/users/:id/restore", m.userHandler.RestoreUser)
+	admin.DELETE("/users/:id", m.userHandler.HardDeleteUser)
+}
+
+func (m *Module) HealthChecks() []healthport.Checker {
+	return nil
+}
+
+func (m *Module) ShutdownHooks() []module.ShutdownHook {
+	return []module.ShutdownHook{{Name: "revocation-purge", Fn: m.stopPurge}}
+}
+
+// stopPurge espera a que termine la purga en curso
+func (m *Module) stopPurge(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
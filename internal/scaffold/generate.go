@@ -0,0 +1,138 @@
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/migrations"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// ErrFileExists se informa cuando alguno de los ficheros a generar ya existe
+var ErrFileExists = errors.New("el fichero ya existe")
+
+// File es un fichero generado con su ruta relativa a la raíz del repositorio
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// templateData expone la especificación y la ruta del módulo Go a las plantillas
+type templateData struct {
+	*Spec
+	Module string
+}
+
+// ModulePath lee la ruta del módulo Go declarada en el go.mod de root
+func ModulePath(root string) (string, error) {
+	file, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("go.mod no declara el módulo en %s", root)
+}
+
+// Render genera todos los ficheros del módulo. El código Go se formatea con gofmt,
+// así que un error de sintaxis en una plantilla se detecta antes de escribir nada.
+func Render(spec *Spec, goModule string, now time.Time) ([]File, error) {
+	moduleDir := path.Join("internal/modules", spec.Package)
+	migration := path.Join(migrations.ModuleDir(spec.Package), fmt.Sprintf("%s_create_%s", migrations.Version(now), spec.Table))
+
+	targets := []struct{ template, path string }{
+		{"module.go.tmpl", path.Join(moduleDir, "module.go")},
+		{"model.go.tmpl", path.Join(moduleDir, "domain/model", spec.Snake+".go")},
+		{"errors.go.tmpl", path.Join(moduleDir, "domain/model", "errors.go")},
+		{"port.go.tmpl", path.Join(moduleDir, "domain/port", spec.Snake+"_repository.go")},
+		{"create.go.tmpl", path.Join(moduleDir, "application", spec.Snake+"_create.go")},
+		{"get.go.tmpl", path.Join(moduleDir, "application", spec.Snake+"_get.go")},
+		{"list.go.tmpl", path.Join(moduleDir, "application", spec.Snake+"_list.go")},
+		{"update.go.tmpl", path.Join(moduleDir, "application", spec.Snake+"_update.go")},
+		{"delete.go.tmpl", path.Join(moduleDir, "application", spec.Snake+"_delete.go")},
+		{"persistence.go.tmpl", path.Join(moduleDir, "infrastructure/persistence", spec.Snake+"_repository_impl.go")},
+		{"migrations.go.tmpl", path.Join(moduleDir, "infrastructure/persistence", "migrations.go")},
+		{"handler.go.tmpl", path.Join("internal/handlers", spec.Snake+".go")},
+		{"mock.go.tmpl", path.Join("tests/mocks", spec.Snake+"_repository_mock.go")},
+		{"usecase_test.go.tmpl", path.Join("tests/modules", spec.Package, "application", spec.Snake+"_test.go")},
+		{"migration.up.sql.tmpl", migration + ".up.sql"},
+		{"migration.mysql.up.sql.tmpl", migration + ".mysql.up.sql"},
+		{"migration.sqlite.up.sql.tmpl", migration + ".sqlite.up.sql"},
+		{"migration.down.sql.tmpl", migration + ".down.sql"},
+	}
+
+	data := templateData{Spec: spec, Module: goModule}
+	files := make([]File, 0, len(targets))
+	for _, target := range targets {
+		content, err := execute(target.template, data)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(target.path, ".go") {
+			if content, err = format.Source(content); err != nil {
+				return nil, fmt.Errorf("formateando %s: %w", target.path, err)
+			}
+		}
+		files = append(files, File{Path: target.path, Content: content})
+	}
+	return files, nil
+}
+
+// Write escribe los ficheros bajo root. Si alguno ya existe no escribe ninguno.
+func Write(root string, files []File) error {
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(root, file.Path)); err == nil {
+			return fmt.Errorf("%w: %s", ErrFileExists, file.Path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		target := filepath.Join(root, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, file.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Instructions describe los pasos manuales que quedan tras generar el módulo
+func Instructions(spec *Spec, goModule string) (string, error) {
+	content, err := execute("wiring.txt.tmpl", templateData{Spec: spec, Module: goModule})
+	return string(content), err
+}
+
+func execute(name string, data templateData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,258 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidModuleName se informa cuando el nombre del módulo no es un identificador válido
+	ErrInvalidModuleName = errors.New("nombre de módulo inválido")
+	// ErrInvalidField se informa cuando una definición de campo no tiene el formato nombre:tipo
+	ErrInvalidField = errors.New("campo inválido")
+)
+
+// repeatedUnderscores colapsa los separadores consecutivos
+var repeatedUnderscores = regexp.MustCompile(`_+`)
+
+// identifierPattern acepta nombres en snake_case, kebab-case o CamelCase que empiezan por letra
+var identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// reservedFields son las columnas que el esqueleto ya define en todos los modelos
+var reservedFields = map[string]bool{"id": true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+// reservedModuleNames chocan con los paquetes o variables que usa el código generado
+var reservedModuleNames = map[string]bool{
+	"model": true, "port": true, "application": true, "persistence": true, "handlers": true, "mocks": true,
+	"module": true, "modules": true, "health": true, "healthport": true,
+	"shared": true, "sharedmodel": true, "apperror": true, "time": true, "http": true, "gin": true, "gorm": true,
+	"err": true, "id": true, "input": true, "page": true, "ids": true, "ctx": true, "context": true,
+}
+
+// fieldType describe cómo se representa un tipo de campo en Go, en el SQL de cada motor
+// y en los tests generados
+type fieldType struct {
+	goType     string
+	sqlType    string
+	mysqlType  string
+	sqliteType string
+	sample     string
+	updated    string
+}
+
+// fieldTypes son los tipos aceptados en --fields
+var fieldTypes = map[string]fieldType{
+	"string":  {goType: "string", sqlType: "TEXT", mysqlType: "TEXT", sqliteType: "TEXT", sample: `"%s de prueba"`, updated: `"%s actualizado"`},
+	"int":     {goType: "int", sqlType: "BIGINT", mysqlType: "BIGINT", sqliteType: "INTEGER", sample: "42", updated: "43"},
+	"int64":   {goType: "int64", sqlType: "BIGINT", mysqlType: "BIGINT", sqliteType: "INTEGER", sample: "42", updated: "43"},
+	"uint":    {goType: "uint", sqlType: "BIGINT", mysqlType: "BIGINT UNSIGNED", sqliteType: "INTEGER", sample: "42", updated: "43"},
+	"float":   {goType: "float64", sqlType: "DOUBLE PRECISION", mysqlType: "DOUBLE", sqliteType: "REAL", sample: "9.5", updated: "10.5"},
+	"float64": {goType: "float64", sqlType: "DOUBLE PRECISION", mysqlType: "DOUBLE", sqliteType: "REAL", sample: "9.5", updated: "10.5"},
+	"bool":    {goType: "bool", sqlType: "BOOLEAN", mysqlType: "BOOLEAN", sqliteType: "BOOLEAN", sample: "true", updated: "false"},
+	"time":    {goType: "time.Time", sqlType: "TIMESTAMPTZ", mysqlType: "DATETIME(6)", sqliteType: "DATETIME", sample: "time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)", updated: "time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)"},
+}
+
+// Field es un campo del modelo generado
+type Field struct {
+	// Name es el nombre en snake_case, usado para la columna y el JSON
+	Name    string
+	GoName  string
+	GoType  string
+	SQLType string
+	// MySQLType y SQLiteType son el tipo de la columna en las migraciones de esos motores
+	MySQLType  string
+	SQLiteType string
+	// Required indica que el campo es obligatorio al crear o reemplazar el recurso
+	Required bool
+	// Sample y Updated son literales Go usados por los tests generados
+	Sample  string
+	Updated string
+}
+
+// Spec describe el módulo a generar y los nombres derivados en cada convención
+type Spec struct {
+	// Snake es el nombre en snake_case (order_item)
+	Snake string
+	// Package es el directorio y paquete Go del módulo (orderitem)
+	Package string
+	// Pascal y Camel son los nombres de tipo y de variable (OrderItem, orderItem)
+	Pascal string
+	Camel  string
+	// Plural es el plural en PascalCase (OrderItems)
+	Plural string
+	// Table es la tabla en snake_case plural (order_items)
+	Table string
+	// Route es el segmento de ruta HTTP (order-items)
+	Route string
+	// Label es el nombre legible usado en comentarios y Swagger (order item)
+	Label string
+	// ErrorCode es el código estable del error de recurso inexistente (ORDER_ITEM_NOT_FOUND)
+	ErrorCode string
+	Fields    []Field
+}
+
+// NewSpec valida el nombre del módulo y la lista de campos con el formato "nombre:tipo,nombre:tipo"
+func NewSpec(name, fields string) (*Spec, error) {
+	if !identifierPattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidModuleName, name)
+	}
+	snake := toSnake(name)
+	table := pluralize(snake)
+
+	spec := &Spec{
+		Snake:     snake,
+		Package:   strings.ReplaceAll(snake, "_", ""),
+		Pascal:    toPascal(snake),
+		Camel:     toCamel(snake),
+		Plural:    toPascal(table),
+		Table:     table,
+		Route:     strings.ReplaceAll(table, "_", "-"),
+		Label:     strings.ReplaceAll(snake, "_", " "),
+		ErrorCode: strings.ToUpper(snake) + "_NOT_FOUND",
+	}
+	if token.IsKeyword(spec.Package) || token.IsKeyword(spec.Camel) {
+		return nil, fmt.Errorf("%w: %q es una palabra reservada de Go", ErrInvalidModuleName, name)
+	}
+	if reservedModuleNames[spec.Camel] || reservedModuleNames[spec.Package] {
+		return nil, fmt.Errorf("%w: %q coincide con un paquete o variable del código generado", ErrInvalidModuleName, name)
+	}
+	if spec.Pascal == spec.Plural {
+		return nil, fmt.Errorf("%w: %q no tiene un plural distinto", ErrInvalidModuleName, name)
+	}
+
+	parsed, err := ParseFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	spec.Fields = parsed
+	return spec, nil
+}
+
+// ParseFields interpreta "title:string,price:int". Los campos de texto son obligatorios.
+func ParseFields(definition string) ([]Field, error) {
+	var fields []Field
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(definition, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, typeName, ok := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		typeName = strings.ToLower(strings.TrimSpace(typeName))
+		if !ok || !identifierPattern.MatchString(name) {
+			return nil, fmt.Errorf("%w: %q debe tener el formato nombre:tipo", ErrInvalidField, part)
+		}
+		kind, ok := fieldTypes[typeName]
+		if !ok {
+			return nil, fmt.Errorf("%w: tipo %q no soportado en %q", ErrInvalidField, typeName, part)
+		}
+
+		snake := toSnake(name)
+		if reservedFields[snake] {
+			return nil, fmt.Errorf("%w: %q ya forma parte del modelo", ErrInvalidField, snake)
+		}
+		if seen[snake] {
+			return nil, fmt.Errorf("%w: %q está repetido", ErrInvalidField, snake)
+		}
+		seen[snake] = true
+
+		fields = append(fields, Field{
+			Name:       snake,
+			GoName:     toPascal(snake),
+			GoType:     kind.goType,
+			SQLType:    kind.sqlType,
+			MySQLType:  kind.mysqlType,
+			SQLiteType: kind.sqliteType,
+			Required:   kind.goType == "string",
+			Sample:     sampleLiteral(kind.sample, snake),
+			Updated:    sampleLiteral(kind.updated, snake),
+		})
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: se necesita al menos un campo", ErrInvalidField)
+	}
+	return fields, nil
+}
+
+// HasTime indica si algún campo necesita importar time
+func (s *Spec) HasTime() bool {
+	for _, field := range s.Fields {
+		if field.GoType == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+func sampleLiteral(format, name string) string {
+	if strings.Contains(format, "%s") {
+		return fmt.Sprintf(format, name)
+	}
+	return format
+}
+
+// toSnake convierte CamelCase, kebab-case o snake_case a snake_case
+func toSnake(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == '_':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' && !unicode.IsUpper(runes[i-1]) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Trim(repeatedUnderscores.ReplaceAllString(b.String(), "_"), "_")
+}
+
+// commonInitialisms se escriben en mayúsculas en los identificadores Go, como ID o URL
+var commonInitialisms = map[string]bool{"id": true, "url": true, "api": true, "http": true, "json": true, "sku": true, "uuid": true}
+
+func toPascal(snake string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(snake, "_") {
+		if word == "" {
+			continue
+		}
+		if commonInitialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+func toCamel(snake string) string {
+	pascal := toPascal(snake)
+	words := strings.SplitN(snake, "_", 2)
+	if commonInitialisms[words[0]] {
+		return strings.ToLower(pascal[:len(words[0])]) + pascal[len(words[0]):]
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+// pluralize aplica las reglas del inglés más habituales a la última palabra
+func pluralize(snake string) string {
+	switch {
+	case strings.HasSuffix(snake, "s"), strings.HasSuffix(snake, "x"), strings.HasSuffix(snake, "z"),
+		strings.HasSuffix(snake, "ch"), strings.HasSuffix(snake, "sh"):
+		return snake + "es"
+	case strings.HasSuffix(snake, "y") && len(snake) > 1 && !strings.ContainsRune("aeiou", rune(snake[len(snake)-2])):
+		return snake[:len(snake)-1] + "ies"
+	default:
+		return snake + "s"
+	}
+}
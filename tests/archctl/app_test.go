@@ -0,0 +1,131 @@
+package archctl_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"go-hexagonal-template/internal/archctl"
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/modules/user/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFlagSet() (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	email := flags.String("email", "", "email del usuario")
+	return flags, email
+}
+
+func TestParseFlags(t *testing.T) {
+	// Arrange
+	flags, email := newFlagSet()
+
+	// Act
+	err := archctl.ParseFlags(flags, []string{"--email", "admin@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", *email)
+}
+
+func TestParseFlags_InvalidArguments(t *testing.T) {
+	tests := map[string][]string{
+		"opción desconocida":   {"--admn"},
+		"argumentos sobrantes": {"--email", "admin@example.com", "extra"},
+		"opción sin valor":     {"--email"},
+		"valor sin opción":     {"admin@example.com"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			flags, _ := newFlagSet()
+
+			err := archctl.ParseFlags(flags, args)
+
+			assert.ErrorIs(t, err, archctl.ErrUsage)
+		})
+	}
+}
+
+func TestRequireFlag(t *testing.T) {
+	assert.NoError(t, archctl.RequireFlag("email", "admin@example.com"))
+
+	err := archctl.RequireFlag("email", "   ")
+	assert.ErrorIs(t, err, archctl.ErrUsage, "Un valor en blanco debería tratarse como ausente")
+	assert.Contains(t, err.Error(), "--email", "El error debería nombrar la opción que falta")
+}
+
+func TestReadPassword(t *testing.T) {
+	tests := map[string]struct {
+		password string
+		stdin    string
+		expected string
+	}{
+		"opción":               {password: "desde-opcion", stdin: "desde-stdin\n", expected: "desde-opcion"},
+		"primera línea":        {stdin: "secreto\notra línea\n", expected: "secreto"},
+		"fin de línea de CRLF": {stdin: "secreto\r\n", expected: "secreto"},
+		"sin salto de línea":   {stdin: "secreto", expected: "secreto"},
+		"entrada vacía":        {stdin: "", expected: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			password, err := archctl.ReadPassword(tt.password, strings.NewReader(tt.stdin))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, password)
+		})
+	}
+}
+
+func TestReadPassword_ReadError(t *testing.T) {
+	// Arrange
+	readErr := errors.New("entrada cerrada")
+
+	// Act
+	_, err := archctl.ReadPassword("", iotest.ErrReader(readErr))
+
+	// Assert
+	assert.ErrorIs(t, err, readErr)
+}
+
+func newSource(t *testing.T, flags map[string]string) *config.Source {
+	t.Helper()
+	source, err := config.NewSource(config.SourceOptions{Flags: flags})
+	require.NoError(t, err)
+	return source
+}
+
+func TestValidate(t *testing.T) {
+	// Arrange
+	source := newSource(t, map[string]string{"DEFAULT_LOCALE": "es"})
+
+	// Act
+	valid := archctl.Validate(source, application.CreateUserInput{Email: "admin@example.com", Name: "Admin", Password: "secreto"})
+	invalid := archctl.Validate(source, application.CreateUserInput{Email: "admin", Name: "Admin", Password: "123"})
+
+	// Assert
+	assert.NoError(t, valid)
+	require.Error(t, invalid)
+	assert.Equal(t, "email debe ser un email válido; password debe tener al menos 6 caracteres", invalid.Error(),
+		"Debería describir cada campo inválido con los mensajes del catálogo")
+}
+
+func TestValidate_UsesDefaultLocale(t *testing.T) {
+	// Arrange
+	source := newSource(t, map[string]string{"DEFAULT_LOCALE": "en"})
+
+	// Act
+	err := archctl.Validate(source, application.CreateUserInput{Email: "admin@example.com", Name: "Admin"})
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, "password is required", err.Error())
+}
@@ -0,0 +1,53 @@
+package archctl_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go-hexagonal-template/internal/archctl"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_InvalidArguments(t *testing.T) {
+	tests := map[string][]string{
+		"sin comando":            nil,
+		"sin subcomando":         {"user"},
+		"subcomando desconocido": {"user", "rename"},
+		"comando desconocido":    {"role", "list"},
+		"falta una opción":       {"user", "create", "--name", "Admin"},
+		"opción desconocida":     {"user", "list", "--all"},
+		"enable con email":       {"user", "enable", "--user", "admin@example.com"},
+		"argumentos sobrantes":   {"config", "validate", "ahora"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Los errores de uso se detectan antes de conectar con la base de datos
+			source := newSource(t, map[string]string{"DB_HOST": "nadie.invalid"})
+			var stdout, stderr bytes.Buffer
+
+			err := archctl.Run(context.Background(), source, args, strings.NewReader(""), &stdout, &stderr)
+
+			assert.ErrorIs(t, err, archctl.ErrUsage)
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestRun_ValidatesBeforeConnecting(t *testing.T) {
+	// Arrange
+	source := newSource(t, map[string]string{"DEFAULT_LOCALE": "es", "DB_HOST": "nadie.invalid"})
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	err := archctl.Run(context.Background(), source, []string{"user", "create", "--email", "admin", "--name", "Admin"}, strings.NewReader("123\n"), &stdout, &stderr)
+
+	// Assert
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, archctl.ErrUsage, "Los datos inválidos no son un error de uso")
+	assert.Equal(t, "email debe ser un email válido; password debe tener al menos 6 caracteres", err.Error())
+}
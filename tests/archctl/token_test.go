@@ -0,0 +1,30 @@
+package archctl_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go-hexagonal-template/internal/archctl"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_TokenIssue_WritesNoticeToStderr(t *testing.T) {
+	// Arrange
+	source, _ := setupDatabase(t)
+	create := []string{"user", "create", "--email", "admin@example.com", "--name", "Admin", "--password", "secreto123"}
+	require.NoError(t, archctl.Run(context.Background(), source, create, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}))
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	err := archctl.Run(context.Background(), source, []string{"token", "issue", "--user", "admin@example.com"}, strings.NewReader(""), &stdout, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"token"`, "Los tokens deberían escribirse en stdout")
+	assert.NotContains(t, stdout.String(), "Sesión", "El aviso no debería mezclarse con los tokens")
+	assert.Contains(t, stderr.String(), "abierta para admin@example.com")
+}
@@ -0,0 +1,81 @@
+package archctl_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hexagonal-template/internal/archctl"
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/module"
+	"go-hexagonal-template/internal/modules"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupDatabase migra una base de datos SQLite temporal y devuelve la configuración con la
+// que la usa archctl y una conexión propia para comprobar los resultados
+func setupDatabase(t *testing.T) (*config.Source, *gorm.DB) {
+	t.Helper()
+	source := newSource(t, map[string]string{
+		"ENV":       "development",
+		"DB_DRIVER": config.DriverSQLite,
+		"DB_NAME":   filepath.Join(t.TempDir(), "archctl.db"),
+	})
+	cfg, err := config.LoadConfig(source)
+	require.NoError(t, err)
+	db, err := cfg.Database.Connect(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	registry, err := module.NewRegistry(modules.All()...)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Database.Migrate(context.Background(), db, registry.Migrations()...))
+	return source, db
+}
+
+func TestRun_UserCreate_Admin(t *testing.T) {
+	// Arrange
+	source, db := setupDatabase(t)
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	err := archctl.Run(context.Background(), source, []string{"user", "create", "--email", "admin@example.com", "--name", "Admin", "--admin"},
+		strings.NewReader("secreto123\n"), &stdout, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Usuario 1 creado (admin@example.com)\n", stdout.String())
+
+	roles, err := persistence.NewRoleRepositoryImpl(db).GetByUserID(context.Background(), 1)
+	require.NoError(t, err)
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	assert.ElementsMatch(t, []string{model.RoleUser, model.RoleAdmin}, names, "El usuario debería recibir el rol admin además del rol por defecto")
+}
+
+func TestRun_UserCreate_EmailTaken(t *testing.T) {
+	// Arrange
+	source, _ := setupDatabase(t)
+	args := []string{"user", "create", "--email", "admin@example.com", "--name", "Admin", "--password", "secreto123", "--admin"}
+	require.NoError(t, archctl.Run(context.Background(), source, args, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}))
+	var stdout bytes.Buffer
+
+	// Act
+	err := archctl.Run(context.Background(), source, args, strings.NewReader(""), &stdout, &bytes.Buffer{})
+
+	// Assert
+	assert.Error(t, err, "No debería poder crearse dos veces el mismo usuario")
+	assert.Empty(t, stdout.String())
+}
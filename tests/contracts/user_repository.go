@@ -0,0 +1,450 @@
+// Package contracts reúne los tests de conformidad que debe superar cualquier
+// implementación de un puerto, sea en memoria o sobre una base de datos
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UserRepositoryFactory crea un repositorio vacío; se invoca una vez por caso
+type UserRepositoryFactory func(t *testing.T) port.UserRepository
+
+// RunUserRepository ejecuta la suite de conformidad de port.UserRepository
+func RunUserRepository(t *testing.T, newRepository UserRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repo port.UserRepository)
+	}{
+		{"Create_AssignsIDAndTimestamps", testCreateAssignsIDAndTimestamps},
+		{"Create_DuplicateEmail", testCreateDuplicateEmail},
+		{"Create_EmailOfDeletedUserStaysTaken", testCreateEmailOfDeletedUserStaysTaken},
+		{"GetByID", testGetByID},
+		{"GetByID_NotFound", testGetByIDNotFound},
+		{"GetByEmail_IgnoresCase", testGetByEmailIgnoresCase},
+		{"GetByEmail_NotFound", testGetByEmailNotFound},
+		{"GetByEmailIncludingDeleted", testGetByEmailIncludingDeleted},
+		{"Get_ReturnsCopies", testGetReturnsCopies},
+		{"Update", testUpdate},
+		{"Update_DuplicateEmail", testUpdateDuplicateEmail},
+		{"Delete_HidesUser", testDeleteHidesUser},
+		{"Delete_NotFound", testDeleteNotFound},
+		{"Restore", testRestore},
+		{"Restore_ActiveUser", testRestoreActiveUser},
+		{"HardDelete", testHardDelete},
+		{"HardDelete_NotFound", testHardDeleteNotFound},
+		{"List_OffsetPagination", testListOffsetPagination},
+		{"List_CursorPagination", testListCursorPagination},
+		{"List_Filters", testListFilters},
+		{"List_InvalidCursor", testListInvalidCursor},
+		{"Create_Concurrent", testCreateConcurrent},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newRepository(t))
+		})
+	}
+}
+
+// createUser guarda un usuario de prueba con el email indicado
+func createUser(t *testing.T, repo port.UserRepository, name, email string) *model.User {
+	t.Helper()
+	user, err := repo.Create(context.Background(), &model.User{Name: name, Email: email, Password: "hash"})
+	require.NoError(t, err, "Error al crear el usuario de prueba")
+	return user
+}
+
+func listUsers(t *testing.T, repo port.UserRepository, query model.UserQuery) *sharedmodel.Page[model.User] {
+	t.Helper()
+	require.NoError(t, query.Normalize(), "La consulta debería ser válida")
+	page, err := repo.List(context.Background(), query)
+	require.NoError(t, err, "Error al listar los usuarios")
+	return page
+}
+
+func emails(users []model.User) []string {
+	result := make([]string, 0, len(users))
+	for _, user := range users {
+		result = append(result, user.Email)
+	}
+	return result
+}
+
+func testCreateAssignsIDAndTimestamps(t *testing.T, repo port.UserRepository) {
+	// Act
+	first := createUser(t, repo, "Ana", "ana@example.com")
+	second := createUser(t, repo, "Luis", "luis@example.com")
+
+	// Assert
+	assert.NotZero(t, first.ID, "Debería asignarse un ID")
+	assert.Greater(t, second.ID, first.ID, "Los IDs deberían ser crecientes")
+	assert.False(t, first.CreatedAt.IsZero(), "Debería asignarse la fecha de creación")
+	assert.False(t, first.UpdatedAt.IsZero(), "Debería asignarse la fecha de actualización")
+}
+
+func testCreateDuplicateEmail(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	createUser(t, repo, "Ana", "ana@example.com")
+
+	// Act
+	user, err := repo.Create(context.Background(), &model.User{Name: "Otra", Email: "ana@example.com", Password: "hash"})
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrEmailTaken, "El email repetido debería rechazarse")
+	assert.Nil(t, user)
+}
+
+func testCreateEmailOfDeletedUserStaysTaken(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	user := createUser(t, repo, "Ana", "ana@example.com")
+	require.NoError(t, repo.Delete(context.Background(), user.ID))
+
+	// Act
+	_, err := repo.Create(context.Background(), &model.User{Name: "Otra", Email: "ana@example.com", Password: "hash"})
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrEmailTaken, "Un usuario eliminado de forma lógica debería conservar su email")
+}
+
+func testGetByID(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	user := createUser(t, repo, "Ana", "ana@example.com")
+
+	// Act
+	found, err := repo.GetByID(context.Background(), user.ID)
+
+	// Assert
+	require.NoError(t, err, "Error al buscar el usuario por ID")
+	assert.Equal(t, user.ID, found.ID)
+	assert.Equal(t, "Ana", found.Name)
+	assert.Equal(t, "ana@example.com", found.Email)
+	assert.Equal(t, "hash", found.Password)
+	assert.True(t, user.CreatedAt.Equal(found.CreatedAt), "La fecha de creación no coincide")
+	assert.True(t, user.UpdatedAt.Equal(found.UpdatedAt), "La fecha de actualización no coincide")
+}
+
+func testGetByIDNotFound(t *testing.T, repo port.UserRepository) {
+	// Act
+	user, err := repo.GetByID(context.Background(), 9999)
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrUserNotFound)
+	assert.Nil(t, user)
+}
+
+func testGetByEmailIgnoresCase(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	user := createUser(t, repo, "Ana", "ana@example.com")
+
+	// Act
+	found, err := repo.GetByEmail(context.Background(), " Ana@Example.COM ")
+
+	// Assert
+	require.NoError(t, err, "La búsqueda por email no debería distinguir mayúsculas ni espacios")
+	assert.Equal(t, user.ID, found.ID)
+}
+
+func testGetByEmailNotFound(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	createUser(t, repo, "Ana", "ana@example.com")
+
+	// Act
+	user, err := repo.GetByEmail(context.Background(), "otra@example.com")
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "No debería devolverse un usuario con otro email")
+	assert.Nil(t, user)
+}
+
+func testGetByEmailIncludingDeleted(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	ctx := context.Background()
+	user := createUser(t, repo, "Ana", "ana@example.com")
+	require.NoError(t, repo.Delete(ctx, user.ID))
+
+	// Act
+	found, err := repo.GetByEmailIncludingDeleted(ctx, "ANA@example.com")
+	_, missingErr := repo.GetByEmailIncludingDeleted(ctx, "otra@example.com")
+
+	// Assert
+	require.NoError(t, err, "Debería encontrarse el usuario eliminado de forma lógica")
+	assert.Equal(t, user.ID, found.ID)
+	assert.True(t, found.DeletedAt.Valid, "El usuario devuelto debería indicar que está eliminado")
+	assert.ErrorIs(t, missingErr, model.ErrUserNotFound)
+}
+
+func testGetReturnsCopies(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	user := createUser(t, repo, "Ana", "ana@example.com")
+	found, err := repo.GetByID(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	// Act
+	user.Name = "Modificado"
+	found.Name = "Modificado"
+
+	// Assert
+	again, err := repo.GetByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ana", again.Name, "Modificar un usuario devuelto no debería cambiar el guardado")
+}
+
+func testUpdate(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	user := createUser(t, repo, "Ana", "ana@example.com")
+	user.Name = "Ana María"
+	user.Email = "anamaria@example.com"
+
+	// Act
+	updated, err := repo.Update(context.Background(), user)
+
+	// Assert
+	require.NoError(t, err, "Error al actualizar el usuario")
+	assert.Equal(t, "Ana María", updated.Name)
+	found, err := repo.GetByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ana María", found.Name, "El cambio debería guardarse")
+	assert.Equal(t, "anamaria@example.com", found.Email, "El cambio debería guardarse")
+	_, err = repo.GetByEmail(context.Background(), "ana@example.com")
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "El email anterior debería quedar libre")
+}
+
+func testUpdateDuplicateEmail(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	createUser(t, repo, "Luis", "luis@example.com")
+	user := createUser(t, repo, "Ana", "ana@example.com")
+
+	// Act
+	user.Email = "luis@example.com"
+	_, err := repo.Update(context.Background(), user)
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrEmailTaken, "No debería poder usarse el email de otro usuario")
+}
+
+func testDeleteHidesUser(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	ctx := context.Background()
+	user := createUser(t, repo, "Ana", "ana@example.com")
+	createUser(t, repo, "Luis", "luis@example.com")
+
+	// Act
+	err := repo.Delete(ctx, user.ID)
+
+	// Assert
+	require.NoError(t, err, "Error al eliminar el usuario")
+	_, err = repo.GetByID(ctx, user.ID)
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "El usuario eliminado no debería encontrarse por ID")
+	_, err = repo.GetByEmail(ctx, user.Email)
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "El usuario eliminado no debería encontrarse por email")
+	assert.Equal(t, []string{"luis@example.com"}, emails(listUsers(t, repo, model.UserQuery{}).Items),
+		"El listado no debería incluir usuarios eliminados")
+	withDeleted := listUsers(t, repo, model.UserQuery{Filter: model.UserFilter{IncludeDeleted: true}})
+	assert.Equal(t, []string{"ana@example.com", "luis@example.com"}, emails(withDeleted.Items),
+		"include_deleted debería incluir los usuarios eliminados")
+	assert.ErrorIs(t, repo.Delete(ctx, user.ID), model.ErrUserNotFound, "Un usuario eliminado no debería eliminarse dos veces")
+}
+
+func testDeleteNotFound(t *testing.T, repo port.UserRepository) {
+	assert.ErrorIs(t, repo.Delete(context.Background(), 9999), model.ErrUserNotFound)
+}
+
+func testRestore(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	ctx := context.Background()
+	user := createUser(t, repo, "Ana", "ana@example.com")
+	require.NoError(t, repo.Delete(ctx, user.ID))
+
+	// Act
+	restored, err := repo.Restore(ctx, user.ID)
+
+	// Assert
+	require.NoError(t, err, "Error al restaurar el usuario")
+	assert.Equal(t, user.ID, restored.ID)
+	assert.Equal(t, user.Email, restored.Email)
+	_, err = repo.GetByID(ctx, user.ID)
+	assert.NoError(t, err, "El usuario restaurado debería volver a encontrarse")
+}
+
+func testRestoreActiveUser(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	user := createUser(t, repo, "Ana", "ana@example.com")
+
+	// Act
+	_, activeErr := repo.Restore(context.Background(), user.ID)
+	_, missingErr := repo.Restore(context.Background(), 9999)
+
+	// Assert
+	assert.ErrorIs(t, activeErr, model.ErrUserNotFound, "Solo deberían restaurarse usuarios eliminados")
+	assert.ErrorIs(t, missingErr, model.ErrUserNotFound)
+}
+
+func testHardDelete(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	ctx := context.Background()
+	active := createUser(t, repo, "Ana", "ana@example.com")
+	deleted := createUser(t, repo, "Luis", "luis@example.com")
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	// Act
+	activeErr := repo.HardDelete(ctx, active.ID)
+	deletedErr := repo.HardDelete(ctx, deleted.ID)
+
+	// Assert
+	require.NoError(t, activeErr, "Error al eliminar definitivamente un usuario activo")
+	require.NoError(t, deletedErr, "Error al eliminar definitivamente un usuario eliminado de forma lógica")
+	_, err := repo.Restore(ctx, deleted.ID)
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "Un usuario eliminado definitivamente no debería poder restaurarse")
+	withDeleted := listUsers(t, repo, model.UserQuery{Filter: model.UserFilter{IncludeDeleted: true}})
+	assert.Empty(t, withDeleted.Items, "No debería quedar ningún usuario")
+	createUser(t, repo, "Ana", "ana@example.com")
+}
+
+func testHardDeleteNotFound(t *testing.T, repo port.UserRepository) {
+	assert.ErrorIs(t, repo.HardDelete(context.Background(), 9999), model.ErrUserNotFound)
+}
+
+func testListOffsetPagination(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	for i := 1; i <= 5; i++ {
+		createUser(t, repo, fmt.Sprintf("Usuario %d", i), fmt.Sprintf("user%d@example.com", i))
+	}
+
+	// Act
+	page := listUsers(t, repo, model.UserQuery{Page: sharedmodel.PageRequest{Limit: 2, Offset: 2}})
+	last := listUsers(t, repo, model.UserQuery{Page: sharedmodel.PageRequest{Limit: 2, Offset: 4}})
+	beyond := listUsers(t, repo, model.UserQuery{Page: sharedmodel.PageRequest{Offset: 10}})
+
+	// Assert
+	require.NotNil(t, page.Total, "La paginación por desplazamiento debería calcular el total")
+	assert.Equal(t, int64(5), *page.Total)
+	assert.Equal(t, []string{"user3@example.com", "user4@example.com"}, emails(page.Items), "Debería ordenarse por id")
+	assert.NotEmpty(t, page.NextCursor, "Debería indicarse que hay más resultados")
+	assert.Equal(t, []string{"user5@example.com"}, emails(last.Items))
+	assert.Empty(t, last.NextCursor, "La última página no debería tener cursor")
+	assert.Equal(t, []model.User{}, beyond.Items, "Fuera de rango la página debería tener una lista vacía")
+}
+
+func testListCursorPagination(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	for i, name := range []string{"carla", "ana", "bea", "ana", "dario"} {
+		createUser(t, repo, name, fmt.Sprintf("%s%d@example.com", name, i))
+	}
+	query := model.UserQuery{
+		Sort: sharedmodel.Sort{Field: "name", Descending: true},
+		Page: sharedmodel.PageRequest{Limit: 2},
+	}
+
+	// Act
+	var names []string
+	for pages := 0; pages < 5; pages++ {
+		page := listUsers(t, repo, query)
+		if pages > 0 {
+			assert.Nil(t, page.Total, "La paginación por cursor no debería calcular el total")
+		}
+		for _, user := range page.Items {
+			names = append(names, user.Name)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Page.Cursor = page.NextCursor
+	}
+
+	// Assert
+	assert.Equal(t, []string{"dario", "carla", "bea", "ana", "ana"}, names,
+		"El cursor debería recorrer todos los usuarios una sola vez, desempatando por id")
+}
+
+func testListFilters(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	ctx := context.Background()
+	createUser(t, repo, "Ana López", "ana@example.com")
+	createUser(t, repo, "Luis 50%", "luis_50@example.com")
+	createUser(t, repo, "Luis 500", "luis500@example.com")
+	deleted := createUser(t, repo, "Luisa 50%", "luisa_50@example.com")
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	// Act
+	byName := listUsers(t, repo, model.UserQuery{Filter: model.UserFilter{Name: "LUIS 50%"}})
+	byEmail := listUsers(t, repo, model.UserQuery{Filter: model.UserFilter{Email: "_50", IncludeDeleted: true}})
+
+	// Assert
+	assert.Equal(t, []string{"luis_50@example.com"}, emails(byName.Items),
+		"El filtro de nombre debería buscar subcadenas sin distinguir mayúsculas ni tratar % como comodín")
+	assert.Equal(t, []string{"luis_50@example.com", "luisa_50@example.com"}, emails(byEmail.Items),
+		"El filtro de email no debería tratar _ como comodín")
+}
+
+func testListInvalidCursor(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	cursor := sharedmodel.Cursor{Sort: "name", Value: "Ana", ID: 5}
+	query := model.UserQuery{
+		Sort: sharedmodel.Sort{Field: "email"},
+		Page: sharedmodel.PageRequest{Cursor: cursor.Encode()},
+	}
+	require.NoError(t, query.Normalize())
+
+	// Act
+	_, err := repo.List(context.Background(), query)
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrInvalidCursor, "Un cursor de otro orden debería rechazarse")
+}
+
+func testCreateConcurrent(t *testing.T, repo port.UserRepository) {
+	// Arrange
+	const writers = 8
+	var wg sync.WaitGroup
+	ids := make(chan uint, writers)
+	results := make(chan error, writers)
+
+	// Act
+	for i := 0; i < writers; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			user, err := repo.Create(context.Background(), &model.User{Name: "Único", Email: fmt.Sprintf("user%d@example.com", i), Password: "hash"})
+			if err == nil {
+				ids <- user.ID
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Create(context.Background(), &model.User{Name: "Repetido", Email: "shared@example.com", Password: "hash"})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	close(results)
+
+	// Assert
+	seen := make(map[uint]bool)
+	for id := range ids {
+		assert.False(t, seen[id], "Cada usuario debería recibir un ID distinto")
+		seen[id] = true
+	}
+	assert.Len(t, seen, writers, "Todas las altas con emails distintos deberían guardarse")
+
+	created := 0
+	for err := range results {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, model.ErrEmailTaken):
+			t.Errorf("error inesperado al crear el email repetido: %v", err)
+		}
+	}
+	assert.Equal(t, 1, created, "Solo una de las altas con el mismo email debería guardarse")
+}
@@ -0,0 +1,47 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-hexagonal-template/internal/handlers"
+	"go-hexagonal-template/internal/infrastructure/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_JWKS(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signingKey, err := auth.NewSigningKey("key-1", auth.AlgorithmEdDSA, private)
+	require.NoError(t, err)
+	keyManager, err := auth.NewKeyManager(signingKey)
+	require.NoError(t, err)
+
+	router := gin.Default()
+	router.GET("/.well-known/jwks.json", handlers.NewAuthHandler(keyManager).JWKS)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response auth.JWKS
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	require.Len(t, response.Keys, 1, "Debería publicarse una clave")
+	assert.Equal(t, "key-1", response.Keys[0].KeyID, "El kid no coincide")
+	assert.Equal(t, "OKP", response.Keys[0].KeyType, "El tipo de clave no coincide")
+	assert.Equal(t, "Ed25519", response.Keys[0].Curve, "La curva no coincide")
+}
@@ -2,12 +2,18 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"go-hexagonal-template/internal/handlers"
+	"go-hexagonal-template/internal/infrastructure/i18n"
+	"go-hexagonal-template/internal/middleware"
+	"go-hexagonal-template/internal/modules/health/application"
 	"go-hexagonal-template/internal/modules/health/domain/model"
 
 	"github.com/gin-gonic/gin"
@@ -15,10 +21,21 @@ import (
 )
 
 func setupHealthTestRouter() *gin.Engine {
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	liveness := application.NewProbeUseCase(time.Second, 0)
+	readiness := application.NewProbeUseCase(time.Second, 0)
+	readiness.Register(application.NewChecker("database", func(ctx context.Context) error { return nil }), 0)
+	return setupProbeTestRouter(translator, application.NewDrainState(), liveness, readiness)
+}
+
+func setupProbeTestRouter(translator *i18n.Translator, drain *application.DrainState, liveness, readiness *application.ProbeUseCase) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
-	healthHandler := handlers.NewHealthHandler()
+	router.Use(middleware.LocaleMiddleware(translator))
+	healthHandler := handlers.NewHealthHandler(translator, drain, liveness, readiness)
 	router.GET("/health", healthHandler.HealthCheck)
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
 	return router
 }
 
@@ -45,6 +62,26 @@ func TestHealthHandler_HealthCheck(t *testing.T) {
 	assert.Equal(t, expectedResponse.Message, response.Message)
 }
 
+func TestHealthHandler_HealthCheck_English(t *testing.T) {
+	// Arrange
+	router := setupHealthTestRouter()
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", bytes.NewBuffer(nil))
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+
+	var response model.Health
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Healthy!", response.Message)
+}
+
 func TestHealthHandler_HealthCheck_WrongMethod(t *testing.T) {
 	// Arrange
 	router := setupHealthTestRouter()
@@ -57,3 +94,144 @@ func TestHealthHandler_HealthCheck_WrongMethod(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
+
+func TestHealthHandler_Livez(t *testing.T) {
+	// Arrange
+	router := setupHealthTestRouter()
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/livez", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response model.Health
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusUp, response.Status)
+}
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	// Arrange
+	router := setupHealthTestRouter()
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response model.Health
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusUp, response.Status)
+	assert.Len(t, response.Checks, 1)
+	assert.Equal(t, "database", response.Checks[0].Name)
+}
+
+func TestHealthHandler_Readyz_DependencyDown(t *testing.T) {
+	// Arrange
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	readiness := application.NewProbeUseCase(time.Second, 0)
+	readiness.Register(application.NewChecker("database", func(ctx context.Context) error {
+		return errors.New("conexión rechazada")
+	}), 0)
+	router := setupProbeTestRouter(translator, application.NewDrainState(), application.NewProbeUseCase(time.Second, 0), readiness)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response model.Health
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusDown, response.Status)
+	assert.Equal(t, "conexión rechazada", response.Checks[0].Error)
+
+	// La liveness no depende de la base de datos
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/livez", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHealthHandler_Draining(t *testing.T) {
+	// Arrange
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	drain := application.NewDrainState()
+	readiness := application.NewProbeUseCase(time.Second, time.Minute)
+	readiness.WatchDrain(drain)
+	router := setupProbeTestRouter(translator, drain, application.NewProbeUseCase(time.Second, 0), readiness)
+
+	// Act
+	drain.Start()
+
+	// Assert
+	for _, path := range []string{"/health", "/readyz"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, "%s debería informar que la instancia no está lista", path)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/livez", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, "La liveness no debería verse afectada por el drenaje")
+}
+
+// poolStatsFunc adapta una función al puerto PoolStatsProvider
+type poolStatsFunc func() (*model.PoolStats, error)
+
+func (f poolStatsFunc) PoolStats() (*model.PoolStats, error) {
+	return f()
+}
+
+func setupPoolStatsTestRouter(provider poolStatsFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler(translator))
+	poolStatsHandler := handlers.NewPoolStatsHandler(application.NewPoolStatsUseCase(provider))
+	router.GET("/dbstats", poolStatsHandler.DatabaseStats)
+	return router
+}
+
+func TestPoolStatsHandler_DatabaseStats(t *testing.T) {
+	// Arrange
+	expected := &model.PoolStats{MaxOpenConnections: 25, OpenConnections: 3, InUse: 2, Idle: 1, WaitCount: 4, WaitDurationMs: 12.5}
+	router := setupPoolStatsTestRouter(func() (*model.PoolStats, error) { return expected, nil })
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dbstats", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response model.PoolStats
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, *expected, response)
+}
+
+func TestPoolStatsHandler_DatabaseStats_Error(t *testing.T) {
+	// Arrange
+	router := setupPoolStatsTestRouter(func() (*model.PoolStats, error) { return nil, errors.New("sql: database is closed") })
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dbstats", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "database is closed", "No debería exponerse el error interno")
+}
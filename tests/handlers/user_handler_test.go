@@ -2,7 +2,9 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,26 +12,52 @@ import (
 	"time"
 
 	"go-hexagonal-template/internal/handlers"
+	"go-hexagonal-template/internal/infrastructure/i18n"
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
 	"go-hexagonal-template/internal/modules/user/application"
 	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
 	"go-hexagonal-template/tests/mocks"
 
 	"go-hexagonal-template/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestRouter() (*gin.Engine, *mocks.UserRepositoryMock) {
+	return setupTestRouterWithRole(model.RoleAdmin)
+}
+
+// setupTestRouterWithRole configura el router asignando el rol indicado al usuario de prueba
+func setupTestRouterWithRole(roleName string) (*gin.Engine, *mocks.UserRepositoryMock) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	router.Use(middleware.ErrorHandler(translator), middleware.LocaleMiddleware(translator))
 	mockRepo := mocks.NewUserRepositoryMock()
-	userHandler := handlers.NewUserHandler(mockRepo)
+	roleRepo := mocks.NewRoleRepositoryMock()
+	_ = roleRepo.AssignToUser(context.Background(), 1, roleName)
+	revocationStore := memory.NewTokenRevocationStore()
+	tokenManager := mocks.NewTokenManager()
+	userHandler := handlers.NewUserHandler(mockRepo, roleRepo, mocks.NewRefreshTokenRepositoryMock(), revocationStore, mocks.NewTxManagerMock(), mocks.NewEventOutboxMock(), tokenManager)
 	router.POST("/users", userHandler.CreateUser)
 	router.POST("/login", userHandler.Login)
+	router.POST("/token/refresh", userHandler.RefreshToken)
 	api := router.Group("/api")
-	api.Use(middleware.AuthMiddleware()) // Usar el middleware real
+	api.Use(middleware.AuthMiddleware(tokenManager, revocationStore)) // Usar el middleware real
+	api.GET("/users", middleware.RequirePermission(model.PermissionUsersRead), userHandler.ListUsers)
 	api.GET("/users/:id", userHandler.GetUser)
+	api.PUT("/users/:id", userHandler.UpdateUser)
+	api.PATCH("/users/:id", userHandler.PatchUser)
+	api.DELETE("/users/:id", userHandler.DeleteUser)
+	api.POST("/logout", userHandler.Logout)
+	api.POST("/logout-all", userHandler.LogoutAll)
+	admin := api.Group("/admin", middleware.RequirePermission(model.PermissionUsersDelete))
+	admin.POST("/users/:id/restore", userHandler.RestoreUser)
+	admin.DELETE("/users/:id", userHandler.HardDeleteUser)
 	return router, mockRepo
 }
 
@@ -38,15 +66,11 @@ func TestUserHandler_GetUser(t *testing.T) {
 	router, mockRepo := setupTestRouter()
 
 	// Crear un usuario de prueba
-	now := time.Now()
 	user := &model.User{
-		ID:        1,
-		Email:     "test@example.com",
-		Name:      "Test",
-		CreatedAt: now,
-		UpdatedAt: now,
+		Email: "created@example.com",
+		Name:  "Created",
 	}
-	createdUser, err := mockRepo.Create(user)
+	createdUser, err := mockRepo.Create(context.Background(), user)
 	assert.NoError(t, err, "Error al crear el usuario para la prueba")
 	assert.NotNil(t, createdUser, "El usuario creado no debería ser nil")
 	assert.NotEmpty(t, createdUser.ID, "El ID del usuario no debería estar vacío")
@@ -121,18 +145,36 @@ func TestUserHandler_GetUser_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code, "El código de estado debería ser 404")
 	assert.NotEmpty(t, w.Body.String(), "El cuerpo de la respuesta no debería estar vacío")
 
-	var response map[string]string
-	err = json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err, "Error al deserializar la respuesta")
-	assert.Contains(t, response, "error", "La respuesta debería contener un campo 'error'")
-	assert.Equal(t, "Usuario no encontrado", response["error"], "El mensaje de error no coincide")
+	problem := decodeProblem(t, w)
+	assert.Equal(t, http.StatusNotFound, problem.Status, "El estado del problema no coincide")
+	assert.Equal(t, "USER_NOT_FOUND", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "Usuario no encontrado", problem.Detail, "El mensaje de error no coincide")
+	assert.Equal(t, "/problems/user-not-found", problem.Type, "El tipo del problema no coincide")
+	assert.Equal(t, "/api/users/9999", problem.Instance, "La instancia del problema no coincide")
+}
+
+func TestUserHandler_GetUser_RepositoryError(t *testing.T) {
+	// Arrange
+	router, mockRepo := setupTestRouter()
+	session := loginTestUser(t, router)
+	mockRepo.Fail(errors.New("conexión con la base de datos perdida"))
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users/1", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "Un fallo de la base de datos debería responder 500 y no 404")
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "INTERNAL_ERROR", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "Error interno del servidor", problem.Detail, "El detalle no debería exponer el error original")
 }
 
 func TestUserHandler_CreateUser(t *testing.T) {
 	// Arrange
 	router, _ := setupTestRouter()
 	input := application.CreateUserInput{
-		Email:    "test@example.com",
+		Email:    "nonexistent@example.com",
 		Name:     "Test",
 		Password: "password123",
 	}
@@ -159,6 +201,30 @@ func TestUserHandler_CreateUser(t *testing.T) {
 	assert.NotEmpty(t, response.UpdatedAt, "La fecha de actualización no debería estar vacía")
 }
 
+func TestUserHandler_CreateUser_EmailTaken(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	jsonInput, _ := json.Marshal(application.CreateUserInput{
+		Email:    "test@example.com",
+		Name:     "Test",
+		Password: "password123",
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonInput))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code, "El código de estado debería ser 409")
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, http.StatusConflict, problem.Status, "El estado del problema no coincide")
+	assert.Equal(t, "EMAIL_TAKEN", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "El email ya está registrado", problem.Detail, "El mensaje de error no coincide")
+}
+
 func TestUserHandler_CreateUser_InvalidInput(t *testing.T) {
 	// Arrange
 	router, _ := setupTestRouter()
@@ -179,11 +245,43 @@ func TestUserHandler_CreateUser_InvalidInput(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code, "El código de estado debería ser 400")
 	assert.NotEmpty(t, w.Body.String(), "El cuerpo de la respuesta no debería estar vacío")
 
-	var response map[string]string
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err, "Error al deserializar la respuesta")
-	assert.Contains(t, response, "error", "La respuesta debería contener un campo 'error'")
-	assert.Equal(t, "Datos de usuario inválidos", response["error"], "El mensaje de error no coincide")
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "VALIDATION_FAILED", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "Los datos enviados no son válidos", problem.Detail, "El mensaje de error no coincide")
+	assert.ElementsMatch(t, []apperror.FieldError{
+		{Field: "email", Code: "email", Message: "debe ser un email válido"},
+		{Field: "name", Code: "required", Message: "es obligatorio"},
+		{Field: "password", Code: "min", Param: "6", Message: "debe tener al menos 6 caracteres"},
+	}, problem.Errors, "Los errores por campo no coinciden")
+}
+
+func TestUserHandler_CreateUser_InvalidInput_English(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	jsonInput, _ := json.Marshal(application.CreateUserInput{
+		Email:    "invalid-email",
+		Name:     "Test",
+		Password: "123",
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonInput))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en-GB,en;q=0.8,es;q=0.5")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code, "El código de estado debería ser 400")
+	assert.Equal(t, "en", w.Header().Get("Content-Language"), "El idioma negociado debería ser inglés")
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "Invalid request", problem.Title, "El título debería estar en inglés")
+	assert.Equal(t, "The submitted data is not valid", problem.Detail, "El detalle debería estar en inglés")
+	assert.ElementsMatch(t, []apperror.FieldError{
+		{Field: "email", Code: "email", Message: "must be a valid email"},
+		{Field: "password", Code: "min", Param: "6", Message: "must be at least 6 characters long"},
+	}, problem.Errors, "Los errores por campo deberían estar en inglés")
 }
 
 func TestUserHandler_Login(t *testing.T) {
@@ -236,11 +334,9 @@ func TestUserHandler_Login_InvalidCredentials(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code, "El código de estado debería ser 401")
 	assert.NotEmpty(t, w.Body.String(), "El cuerpo de la respuesta no debería estar vacío")
 
-	var response map[string]string
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err, "Error al deserializar la respuesta")
-	assert.Contains(t, response, "error", "La respuesta debería contener un campo 'error'")
-	assert.Equal(t, "Credenciales inválidas", response["error"], "El mensaje de error no coincide")
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "INVALID_CREDENTIALS", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "Credenciales inválidas", problem.Detail, "El mensaje de error no coincide")
 }
 
 func TestUserHandler_GetUser_WithAuth(t *testing.T) {
@@ -248,15 +344,11 @@ func TestUserHandler_GetUser_WithAuth(t *testing.T) {
 	router, mockRepo := setupTestRouter()
 
 	// Crear un usuario de prueba
-	now := time.Now()
 	user := &model.User{
-		ID:        2,
-		Email:     "test@example.com",
-		Name:      "Test",
-		CreatedAt: now,
-		UpdatedAt: now,
+		Email: "created@example.com",
+		Name:  "Created",
 	}
-	createdUser, err := mockRepo.Create(user)
+	createdUser, err := mockRepo.Create(context.Background(), user)
 	assert.NoError(t, err, "Error al crear el usuario para la prueba")
 	assert.NotNil(t, createdUser, "El usuario creado no debería ser nil")
 	assert.NotEmpty(t, createdUser.ID, "El ID del usuario no debería estar vacío")
@@ -313,9 +405,462 @@ func TestUserHandler_GetUser_WithoutAuth(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code, "El código de estado debería ser 401")
 	assert.NotEmpty(t, w.Body.String(), "El cuerpo de la respuesta no debería estar vacío")
 
-	var response map[string]string
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "MISSING_TOKEN", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "No se proporcionó token de autenticación", problem.Detail, "El mensaje de error no coincide")
+}
+
+func TestUserHandler_RefreshToken(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	loginJson, _ := json.Marshal(map[string]string{
+		"email":    "test@example.com",
+		"password": "password123",
+	})
+	loginReq, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(loginJson))
+	loginReq.Header.Set("Content-Type", "application/json")
+	loginW := httptest.NewRecorder()
+	router.ServeHTTP(loginW, loginReq)
+
+	var loginResponse application.LoginUserOutput
+	err := json.Unmarshal(loginW.Body.Bytes(), &loginResponse)
+	assert.NoError(t, err, "Error al deserializar la respuesta del login")
+	assert.NotEmpty(t, loginResponse.RefreshToken, "El refresh token no debería estar vacío")
+
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(application.RefreshTokenInput{RefreshToken: refreshToken})
+		req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	w := refresh(loginResponse.RefreshToken)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response application.TokenPair
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	assert.NotEmpty(t, response.Token, "El token no debería estar vacío")
+	assert.NotEqual(t, loginResponse.RefreshToken, response.RefreshToken, "El refresh token debería rotar")
+
+	// Reutilizar el refresh token original revoca la sesión completa
+	assert.Equal(t, http.StatusUnauthorized, refresh(loginResponse.RefreshToken).Code, "El token reutilizado debería ser rechazado")
+	assert.Equal(t, http.StatusUnauthorized, refresh(response.RefreshToken).Code, "La familia de tokens debería estar revocada")
+}
+
+func TestUserHandler_RefreshToken_InvalidInput(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBuffer([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code, "El código de estado debería ser 400")
+}
+
+func loginTestUser(t *testing.T, router *gin.Engine) application.LoginUserOutput {
+	loginJson, _ := json.Marshal(map[string]string{
+		"email":    "test@example.com",
+		"password": "password123",
+	})
+	loginReq, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(loginJson))
+	loginReq.Header.Set("Content-Type", "application/json")
+	loginW := httptest.NewRecorder()
+	router.ServeHTTP(loginW, loginReq)
+
+	var loginResponse application.LoginUserOutput
+	err := json.Unmarshal(loginW.Body.Bytes(), &loginResponse)
+	assert.NoError(t, err, "Error al deserializar la respuesta del login")
+	assert.NotEmpty(t, loginResponse.Token, "El token no debería estar vacío")
+	return loginResponse
+}
+
+func authorizedRequest(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(nil))
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func authorizedJSONRequest(router *gin.Engine, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// decodeProblem verifica que la respuesta sea application/problem+json y la deserializa
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) middleware.Problem {
+	assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"), "El tipo de contenido debería ser problem+json")
+
+	var problem middleware.Problem
+	err := json.Unmarshal(w.Body.Bytes(), &problem)
+	assert.NoError(t, err, "Error al deserializar el problema")
+	assert.Equal(t, w.Code, problem.Status, "El estado del problema debería coincidir con el de la respuesta")
+	return problem
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+	other := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "POST", "/api/logout", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code, "El código de estado debería ser 204")
+
+	w = authorizedRequest(router, "GET", "/api/users/1", session.Token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "El token revocado debería ser rechazado")
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "TOKEN_REVOKED", problem.Code, "El código de error no coincide")
+
+	body, _ := json.Marshal(application.RefreshTokenInput{RefreshToken: session.RefreshToken})
+	req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	refreshW := httptest.NewRecorder()
+	router.ServeHTTP(refreshW, req)
+	assert.Equal(t, http.StatusUnauthorized, refreshW.Code, "El refresh token de la sesión debería estar revocado")
+
+	// Las demás sesiones siguen activas
+	w = authorizedRequest(router, "GET", "/api/users/1", other.Token)
+	assert.Equal(t, http.StatusOK, w.Code, "Otras sesiones no deberían verse afectadas")
+}
+
+func TestUserHandler_LogoutAll(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	first := loginTestUser(t, router)
+	second := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "POST", "/api/logout-all", first.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code, "El código de estado debería ser 204")
+	assert.Equal(t, http.StatusUnauthorized, authorizedRequest(router, "GET", "/api/users/1", first.Token).Code, "El primer token debería estar revocado")
+	assert.Equal(t, http.StatusUnauthorized, authorizedRequest(router, "GET", "/api/users/1", second.Token).Code, "El segundo token debería estar revocado")
+}
+
+func TestUserHandler_LogoutAll_RevokesTokensFromSameSecond(t *testing.T) {
+	// Arrange: el login y el cierre de sesiones deben caer en el mismo segundo
+	router, _ := setupTestRouter()
+	if untilNextSecond := time.Until(time.Now().Truncate(time.Second).Add(time.Second)); untilNextSecond < 500*time.Millisecond {
+		time.Sleep(untilNextSecond)
+	}
+	start := time.Now()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "POST", "/api/logout-all", session.Token)
+
+	// Assert
+	require.Equal(t, start.Unix(), time.Now().Unix(), "El login y el cierre deberían ocurrir en el mismo segundo")
+	assert.Equal(t, http.StatusNoContent, w.Code, "El código de estado debería ser 204")
+	assert.Equal(t, http.StatusUnauthorized, authorizedRequest(router, "GET", "/api/users/1", session.Token).Code,
+		"Un token emitido en el mismo segundo que el cierre debería estar revocado")
+}
+
+func TestUserHandler_GetUser_Forbidden(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users/2", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code, "El código de estado debería ser 403")
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "FORBIDDEN", problem.Code, "El código de error no coincide")
+	assert.Equal(t, "No tienes permisos para realizar esta acción", problem.Detail, "El mensaje de error no coincide")
+}
+
+func TestUserHandler_GetUser_Self(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users/1", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "Un usuario debería poder consultarse a sí mismo")
+}
+
+func TestUserHandler_GetUser_ForbiddenDoesNotLeakExistence(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users/9999", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code, "Un usuario inexistente ajeno debería responder 403 y no 404")
+}
+
+func TestUserHandler_ListUsers(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response sharedmodel.Page[model.User]
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	assert.Len(t, response.Items, 2, "Deberían listarse todos los usuarios")
+	assert.Equal(t, sharedmodel.DefaultPageLimit, response.Limit, "Debería aplicarse el límite por defecto")
+	assert.Empty(t, response.NextCursor, "No debería haber página siguiente")
+	assert.Empty(t, w.Header().Get("Link"), "No debería haber cabecera Link")
+}
+
+func TestUserHandler_ListUsers_OffsetPagination(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users?limit=1&email=example", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response sharedmodel.Page[model.User]
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	assert.Len(t, response.Items, 1, "Debería respetarse el límite")
+	assert.NotEmpty(t, response.NextCursor, "Debería haber página siguiente")
+	assert.Equal(t, int64(2), *response.Total, "El total no coincide")
+	assert.Equal(t, `</api/users?email=example&limit=1&offset=1>; rel="next"`, w.Header().Get("Link"), "La cabecera Link debería apuntar a la página siguiente conservando los filtros")
+
+	// La última página enlaza solo con la anterior
+	w = authorizedRequest(router, "GET", "/api/users?limit=1&offset=1", session.Token)
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+	assert.Equal(t, `</api/users?limit=1&offset=0>; rel="prev"`, w.Header().Get("Link"), "La cabecera Link debería apuntar a la página anterior")
+}
+
+func TestUserHandler_ListUsers_CursorPagination(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+	first := authorizedRequest(router, "GET", "/api/users?limit=1", session.Token)
+	var firstPage sharedmodel.Page[model.User]
+	_ = json.Unmarshal(first.Body.Bytes(), &firstPage)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users?limit=1&cursor="+firstPage.NextCursor, session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response sharedmodel.Page[model.User]
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err, "Error al deserializar la respuesta")
-	assert.Contains(t, response, "error", "La respuesta debería contener un campo 'error'")
-	assert.Equal(t, "No se proporcionó token de autenticación", response["error"], "El mensaje de error no coincide")
+	assert.Len(t, response.Items, 1, "Debería respetarse el límite")
+	assert.Equal(t, uint(2), response.Items[0].ID, "El cursor debería continuar tras el último elemento")
+	assert.Empty(t, response.NextCursor, "No debería haber página siguiente")
+}
+
+func TestUserHandler_ListUsers_InvalidQuery(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	for _, query := range []string{
+		"limit=abc",
+		"sort=password",
+		"include_deleted=maybe",
+		"created_after=ayer",
+		"cursor=invalido",
+		"cursor=abc&offset=10",
+	} {
+		// Act
+		w := authorizedRequest(router, "GET", "/api/users?"+query, session.Token)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, "La consulta %q debería responder 400", query)
+	}
+}
+
+func TestUserHandler_ListUsers_Forbidden(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "GET", "/api/users", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code, "Listar usuarios debería requerir users:read")
+}
+
+func TestUserHandler_UpdateUser(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedJSONRequest(router, "PUT", "/api/users/1", session.Token, map[string]string{
+		"email": "updated@example.com",
+		"name":  "Updated",
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response model.User
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	assert.Equal(t, "updated@example.com", response.Email, "El email no coincide")
+	assert.Equal(t, "Updated", response.Name, "El nombre no coincide")
+}
+
+func TestUserHandler_UpdateUser_InvalidInput(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedJSONRequest(router, "PUT", "/api/users/1", session.Token, map[string]string{
+		"name": "Sin email",
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code, "PUT debería requerir todos los campos")
+}
+
+func TestUserHandler_PatchUser(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedJSONRequest(router, "PATCH", "/api/users/1", session.Token, map[string]string{
+		"name": "Patched",
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response model.User
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	assert.Equal(t, "Patched", response.Name, "El nombre no coincide")
+	assert.Equal(t, "test@example.com", response.Email, "El email no debería cambiar")
+}
+
+func TestUserHandler_PatchUser_Forbidden(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedJSONRequest(router, "PATCH", "/api/users/2", session.Token, map[string]string{
+		"name": "Patched",
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code, "Actualizar a otro usuario debería requerir users:write")
+}
+
+func TestUserHandler_DeleteUser(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "DELETE", "/api/users/1", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code, "El código de estado debería ser 204")
+	assert.Equal(t, http.StatusUnauthorized, authorizedRequest(router, "GET", "/api/users/1", session.Token).Code, "Las sesiones del usuario eliminado deberían estar revocadas")
+}
+
+func TestUserHandler_DeleteUser_NotFound(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "DELETE", "/api/users/9999", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code, "El código de estado debería ser 404")
+}
+
+func TestUserHandler_RestoreUser(t *testing.T) {
+	// Arrange
+	router, mockRepo := setupTestRouter()
+	session := loginTestUser(t, router)
+	require.NoError(t, mockRepo.Delete(context.Background(), 2))
+
+	// Act
+	w := authorizedRequest(router, "POST", "/api/admin/users/2/restore", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "El código de estado debería ser 200")
+
+	var response model.User
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Error al deserializar la respuesta")
+	assert.Equal(t, uint(2), response.ID, "El ID no coincide")
+}
+
+func TestUserHandler_AdminRoutes_Forbidden(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouterWithRole(model.RoleUser)
+	session := loginTestUser(t, router)
+
+	// Act & Assert
+	assert.Equal(t, http.StatusForbidden, authorizedRequest(router, "POST", "/api/admin/users/1/restore", session.Token).Code, "Restaurar debería requerir users:delete")
+	assert.Equal(t, http.StatusForbidden, authorizedRequest(router, "DELETE", "/api/admin/users/1", session.Token).Code, "Eliminar definitivamente debería requerir users:delete")
+}
+
+func TestUserHandler_HardDeleteUser(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedRequest(router, "DELETE", "/api/admin/users/2", session.Token)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code, "El código de estado debería ser 204")
+}
+
+func TestUserHandler_PatchUser_EmailTaken(t *testing.T) {
+	// Arrange
+	router, _ := setupTestRouter()
+	session := loginTestUser(t, router)
+
+	// Act
+	w := authorizedJSONRequest(router, "PATCH", "/api/users/1", session.Token, map[string]string{
+		"email": "other@example.com",
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code, "Usar el email de otro usuario debería responder 409")
 }
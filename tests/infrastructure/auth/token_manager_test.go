@@ -0,0 +1,234 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePrivateKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "private.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func writePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func generateKey(t *testing.T, algorithm string) crypto.Signer {
+	var (
+		key crypto.Signer
+		err error
+	)
+	switch algorithm {
+	case auth.AlgorithmRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case auth.AlgorithmES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case auth.AlgorithmEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+	return key
+}
+
+func TestTokenManager_AsymmetricAlgorithms(t *testing.T) {
+	for _, algorithm := range []string{auth.AlgorithmRS256, auth.AlgorithmES256, auth.AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			// Arrange
+			signingKey, err := auth.LoadPrivateKey(writePrivateKey(t, generateKey(t, algorithm)), algorithm, "")
+			require.NoError(t, err, "Error al cargar la clave privada")
+			keyManager, err := auth.NewKeyManager(signingKey)
+			require.NoError(t, err)
+			tokenManager := auth.NewTokenManager(keyManager, auth.TokenOptions{})
+
+			// Act
+			tokenString, err := tokenManager.GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com", SessionID: "session"})
+			require.NoError(t, err, "Error al generar el token")
+			claims, err := tokenManager.ValidateToken(tokenString)
+
+			// Assert
+			assert.NoError(t, err, "El token debería ser válido")
+			assert.Equal(t, "1", claims.UserID, "El ID no coincide")
+			assert.NotEmpty(t, claims.ID, "El token debería tener jti")
+
+			token, _, err := jwt.NewParser().ParseUnverified(tokenString, &auth.Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, algorithm, token.Method.Alg(), "El algoritmo no coincide")
+			assert.Equal(t, signingKey.ID, token.Header["kid"], "El kid no coincide")
+
+			jwks := keyManager.JWKS()
+			require.Len(t, jwks.Keys, 1, "Debería publicarse una clave")
+			assert.Equal(t, signingKey.ID, jwks.Keys[0].KeyID, "El kid publicado no coincide")
+			assert.Equal(t, jwks.Keys[0].Thumbprint(), signingKey.ID, "El kid por defecto debería ser la huella de la clave")
+			assert.Equal(t, algorithm, jwks.Keys[0].Algorithm, "El algoritmo publicado no coincide")
+		})
+	}
+}
+
+func TestTokenManager_Rotation(t *testing.T) {
+	// Arrange
+	oldPrivate := generateKey(t, auth.AlgorithmRS256)
+	oldKey, err := auth.NewSigningKey("old", auth.AlgorithmRS256, oldPrivate)
+	require.NoError(t, err)
+	oldKeyManager, err := auth.NewKeyManager(oldKey)
+	require.NoError(t, err)
+	oldToken, err := auth.NewTokenManager(oldKeyManager, auth.TokenOptions{}).GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com"})
+	require.NoError(t, err)
+
+	newKey, err := auth.NewSigningKey("new", auth.AlgorithmES256, generateKey(t, auth.AlgorithmES256))
+	require.NoError(t, err)
+	previousKey, err := auth.LoadPublicKey(writePublicKey(t, oldPrivate.Public()), auth.AlgorithmRS256, "old")
+	require.NoError(t, err)
+	previousKey.ExpiresAt = time.Now().Add(time.Minute)
+	keyManager, err := auth.NewKeyManager(newKey, previousKey)
+	require.NoError(t, err)
+	tokenManager := auth.NewTokenManager(keyManager, auth.TokenOptions{})
+
+	// Act
+	_, err = tokenManager.ValidateToken(oldToken)
+
+	// Assert
+	assert.NoError(t, err, "Los tokens de la clave anterior deberían aceptarse durante la ventana de rotación")
+	assert.Len(t, keyManager.JWKS().Keys, 2, "Ambas claves deberían publicarse")
+
+	// Al vencer la ventana la clave anterior deja de aceptarse y de publicarse
+	previousKey.ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = tokenManager.ValidateToken(oldToken)
+	assert.Error(t, err, "Los tokens de la clave retirada deberían rechazarse")
+	assert.Len(t, keyManager.JWKS().Keys, 1, "Solo la clave actual debería publicarse")
+}
+
+func TestTokenManager_RejectsAlgorithmConfusion(t *testing.T) {
+	// Arrange
+	private := generateKey(t, auth.AlgorithmRS256)
+	signingKey, err := auth.NewSigningKey("rsa", auth.AlgorithmRS256, private)
+	require.NoError(t, err)
+	keyManager, err := auth.NewKeyManager(signingKey)
+	require.NoError(t, err)
+	tokenManager := auth.NewTokenManager(keyManager, auth.TokenOptions{})
+
+	// Token HS256 firmado con la clave pública como secreto
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.Claims{UserID: "1"})
+	forged.Header["kid"] = "rsa"
+	forgedString, err := forged.SignedString(publicDER)
+	require.NoError(t, err)
+
+	// Act
+	_, err = tokenManager.ValidateToken(forgedString)
+
+	// Assert
+	assert.Error(t, err, "Un token con otro algoritmo debería rechazarse")
+}
+
+func TestTokenManager_RejectsUnknownKey(t *testing.T) {
+	// Arrange
+	tokenManager := auth.NewTokenManager(mustKeyManager(t, auth.NewHMACKey("current", []byte("current-secret"))), auth.TokenOptions{})
+	otherToken, err := auth.NewTokenManager(mustKeyManager(t, auth.NewHMACKey("other", []byte("current-secret"))), auth.TokenOptions{}).
+		GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com"})
+	require.NoError(t, err)
+
+	// Act
+	_, err = tokenManager.ValidateToken(otherToken)
+
+	// Assert
+	assert.ErrorIs(t, err, auth.ErrUnknownKey, "Un kid desconocido debería rechazarse")
+}
+
+func TestKeyManager_RejectsMismatchedKey(t *testing.T) {
+	// Act
+	_, err := auth.LoadPrivateKey(writePrivateKey(t, generateKey(t, auth.AlgorithmEdDSA)), auth.AlgorithmRS256, "")
+
+	// Assert
+	assert.Error(t, err, "Una clave Ed25519 no debería aceptarse para RS256")
+}
+
+func TestKeyManager_HMACKeysAreNotPublished(t *testing.T) {
+	// Arrange
+	keyManager := mustKeyManager(t, auth.NewHMACKey("hmac", []byte("secret")))
+
+	// Assert
+	assert.Empty(t, keyManager.JWKS().Keys, "Las claves simétricas nunca deberían publicarse")
+}
+
+func mustKeyManager(t *testing.T, current *auth.SigningKey) *auth.KeyManager {
+	keyManager, err := auth.NewKeyManager(current)
+	require.NoError(t, err)
+	return keyManager
+}
+
+func TestTokenManager_EnforcesIssuerAndAudience(t *testing.T) {
+	// Arrange
+	keyManager := mustKeyManager(t, auth.NewHMACKey("key", []byte("shared-secret")))
+	tokenManager := auth.NewTokenManager(keyManager, auth.TokenOptions{Issuer: "issuer", Audience: "api"})
+	otherIssuer := auth.NewTokenManager(keyManager, auth.TokenOptions{Issuer: "other", Audience: "api"})
+	otherAudience := auth.NewTokenManager(keyManager, auth.TokenOptions{Issuer: "issuer", Audience: "other-api"})
+
+	validToken, err := tokenManager.GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com"})
+	require.NoError(t, err)
+	wrongIssuerToken, err := otherIssuer.GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com"})
+	require.NoError(t, err)
+	wrongAudienceToken, err := otherAudience.GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com"})
+	require.NoError(t, err)
+
+	// Act
+	claims, err := tokenManager.ValidateToken(validToken)
+
+	// Assert
+	assert.NoError(t, err, "El token debería ser válido")
+	assert.Equal(t, "issuer", claims.Issuer, "El emisor no coincide")
+	assert.Equal(t, "1", claims.Subject, "El sujeto no coincide")
+
+	_, err = tokenManager.ValidateToken(wrongIssuerToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer, "Un emisor distinto debería rechazarse")
+
+	_, err = tokenManager.ValidateToken(wrongAudienceToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience, "Una audiencia distinta debería rechazarse")
+}
+
+func TestTokenManager_RejectsExpiredTokensOutsideLeeway(t *testing.T) {
+	// Arrange
+	keyManager := mustKeyManager(t, auth.NewHMACKey("key", []byte("shared-secret")))
+	expired := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.Claims{
+		UserID: "1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-10 * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	})
+	expired.Header["kid"] = "key"
+	expiredString, err := expired.SignedString([]byte("shared-secret"))
+	require.NoError(t, err)
+
+	// Act
+	_, strictErr := auth.NewTokenManager(keyManager, auth.TokenOptions{}).ValidateToken(expiredString)
+	_, lenientErr := auth.NewTokenManager(keyManager, auth.TokenOptions{Leeway: time.Minute}).ValidateToken(expiredString)
+
+	// Assert
+	assert.ErrorIs(t, strictErr, jwt.ErrTokenExpired, "El token vencido debería rechazarse")
+	assert.NoError(t, lenientErr, "El token debería aceptarse dentro de la tolerancia")
+}
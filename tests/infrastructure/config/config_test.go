@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV", "development")
+	t.Setenv("PORT", "")
+	source, err := config.NewSource(config.SourceOptions{})
+	require.NoError(t, err)
+
+	// Act
+	cfg, err := config.LoadConfig(source)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "3000", cfg.Port)
+	assert.Equal(t, config.DriverPostgres, cfg.Database.Driver)
+	assert.NotNil(t, cfg.Tokens)
+	assert.Nil(t, cfg.DB, "LoadConfig no debe conectar con la base de datos")
+}
+
+func TestLoadConfig_ReportsEveryProblem(t *testing.T) {
+	// Arrange: valores inválidos en varias secciones
+	source, err := config.NewSource(config.SourceOptions{Flags: map[string]string{
+		"ENV":                  "development",
+		"DB_DRIVER":            "oracle",
+		"HEALTH_CHECK_TIMEOUT": "rápido",
+		"SERVER_READ_TIMEOUT":  "-1s",
+		"JWT_ACCESS_TOKEN_TTL": "0s",
+		"DEFAULT_LOCALE":       "xx",
+	}})
+	require.NoError(t, err)
+
+	// Act
+	_, err = config.LoadConfig(source)
+
+	// Assert
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr), "debe devolver un ValidationError")
+	message := err.Error()
+	for _, key := range []string{"DB_DRIVER", "HEALTH_CHECK_TIMEOUT", "SERVER_READ_TIMEOUT", "JWT_ACCESS_TOKEN_TTL", "DEFAULT_LOCALE"} {
+		assert.Contains(t, message, key, "debe informar de todos los problemas a la vez")
+	}
+}
@@ -0,0 +1,364 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/retry"
+	"go-hexagonal-template/internal/infrastructure/transaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type timeoutRecord struct {
+	ID uint
+}
+
+// setupTimeoutDB abre una conexión DryRun con el plugin y registra el plazo de cada consulta
+func setupTimeoutDB(t *testing.T, timeout time.Duration) (*gorm.DB, *[]time.Duration) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err, "Error al crear la base de datos en modo DryRun")
+	require.NoError(t, db.Use(config.NewQueryTimeoutPlugin(timeout)))
+
+	var remaining []time.Duration
+	err = db.Callback().Query().After("gorm:query").Register("test:deadline", func(tx *gorm.DB) {
+		deadline, ok := tx.Statement.Context.Deadline()
+		if !ok {
+			remaining = append(remaining, 0)
+			return
+		}
+		remaining = append(remaining, time.Until(deadline))
+	})
+	require.NoError(t, err)
+	return db, &remaining
+}
+
+func TestNewDatabaseConfig_QueryTimeout(t *testing.T) {
+	databaseConfig, err := config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, databaseConfig.QueryTimeout)
+
+	t.Setenv("DB_QUERY_TIMEOUT", "0")
+	databaseConfig, err = config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Zero(t, databaseConfig.QueryTimeout, "0 debería desactivar el timeout")
+}
+
+func TestNewDatabaseConfig_InvalidQueryTimeout(t *testing.T) {
+	for _, value := range []string{"lento", "-1s"} {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv("DB_QUERY_TIMEOUT", value)
+
+			_, err := config.NewDatabaseConfig()
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestQueryTimeoutPlugin_AppliesDeadline(t *testing.T) {
+	// Arrange
+	db, remaining := setupTimeoutDB(t, 2*time.Second)
+	ctx := context.Background()
+
+	// Act
+	var records []timeoutRecord
+	require.NoError(t, db.WithContext(ctx).Find(&records).Error)
+
+	// Assert
+	require.Len(t, *remaining, 1)
+	assert.Greater(t, (*remaining)[0], time.Duration(0), "La consulta debería tener plazo")
+	assert.LessOrEqual(t, (*remaining)[0], 2*time.Second)
+}
+
+func TestQueryTimeoutPlugin_KeepsShorterRequestDeadline(t *testing.T) {
+	// Arrange
+	db, remaining := setupTimeoutDB(t, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+	var records []timeoutRecord
+	require.NoError(t, db.WithContext(ctx).Find(&records).Error)
+
+	// Assert
+	require.Len(t, *remaining, 1)
+	assert.LessOrEqual(t, (*remaining)[0], time.Second, "Debería mandar el plazo de la petición si vence antes")
+}
+
+func TestQueryTimeoutPlugin_RestoresContextForReusedStatements(t *testing.T) {
+	// Arrange
+	db, remaining := setupTimeoutDB(t, 2*time.Second)
+	tx := db.WithContext(context.Background()).Model(&timeoutRecord{})
+
+	// Act
+	var count int64
+	require.NoError(t, tx.Count(&count).Error)
+	var records []timeoutRecord
+	require.NoError(t, tx.Find(&records).Error)
+
+	// Assert
+	require.Len(t, *remaining, 2)
+	assert.Greater(t, (*remaining)[1], time.Duration(0), "La segunda consulta no debería heredar un contexto cancelado")
+	assert.NoError(t, tx.Statement.Context.Err(), "El contexto original no debería quedar cancelado")
+}
+
+func TestNewDatabaseConfig_Driver(t *testing.T) {
+	databaseConfig, err := config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Equal(t, config.DriverPostgres, databaseConfig.Driver, "Postgres debería ser el motor por defecto")
+
+	t.Setenv("DB_DRIVER", "sqlite")
+	databaseConfig, err = config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Equal(t, config.DriverSQLite, databaseConfig.Driver)
+
+	t.Setenv("DB_DRIVER", "oracle")
+	_, err = config.NewDatabaseConfig()
+	assert.Error(t, err, "Un motor desconocido debería rechazarse")
+}
+
+func TestDatabaseConfig_GetDSN(t *testing.T) {
+	base := config.DatabaseConfig{
+		Host:     "db",
+		Port:     "5432",
+		User:     "app",
+		Password: "p@ss",
+		DBName:   "users",
+		SSLMode:  "disable",
+	}
+	tests := []struct {
+		driver string
+		dsn    string
+		name   string
+	}{
+		{config.DriverPostgres, "host=db port=5432 user=app password=p@ss dbname=users sslmode=disable", "postgres"},
+		{config.DriverMySQL, "app:p@ss@tcp(db:5432)/users?multiStatements=true&parseTime=true&charset=utf8mb4", "mysql"},
+		{config.DriverSQLite, "users?_pragma=foreign_keys%281%29&_pragma=busy_timeout%285000%29&_pragma=journal_mode%28WAL%29", "sqlite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			databaseConfig := base
+			databaseConfig.Driver = tt.driver
+
+			assert.Equal(t, tt.dsn, databaseConfig.GetDSN())
+			assert.Equal(t, tt.name, databaseConfig.Dialector().Name(), "Debería usarse el dialecto del motor")
+		})
+	}
+}
+
+func TestDatabaseConfig_GetDSN_ConnectAttemptTimeout(t *testing.T) {
+	postgresConfig := config.DatabaseConfig{Driver: config.DriverPostgres, ConnectAttemptTimeout: 1500 * time.Millisecond}
+	mysqlConfig := config.DatabaseConfig{Driver: config.DriverMySQL, Host: "db", Port: "3306", ConnectAttemptTimeout: 2 * time.Second}
+
+	assert.Contains(t, postgresConfig.GetDSN(), "connect_timeout=2", "Postgres debería redondear el plazo a segundos enteros")
+	assert.Contains(t, mysqlConfig.GetDSN(), "timeout=2s", "MySQL debería limitar el establecimiento de la conexión")
+}
+
+func TestDatabaseConfig_MySQLDSN_TLS(t *testing.T) {
+	databaseConfig := config.DatabaseConfig{Driver: config.DriverMySQL, Host: "db", Port: "3306", SSLMode: "verify-full"}
+
+	assert.Contains(t, databaseConfig.GetDSN(), "tls=true", "verify-full debería verificar el certificado")
+}
+
+func TestNewDatabaseConfig_Pool(t *testing.T) {
+	databaseConfig, err := config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 25, databaseConfig.MaxOpenConns)
+	assert.Equal(t, 10, databaseConfig.MaxIdleConns)
+	assert.Equal(t, 30*time.Minute, databaseConfig.ConnMaxLifetime)
+	assert.Equal(t, 5*time.Minute, databaseConfig.ConnMaxIdleTime)
+	assert.Empty(t, databaseConfig.ReplicaDSNs, "Sin réplicas configuradas todo debería ir al primario")
+
+	t.Setenv("DB_MAX_OPEN_CONNS", "50")
+	t.Setenv("DB_MAX_IDLE_CONNS", "0")
+	t.Setenv("DB_CONN_MAX_LIFETIME", "1h")
+	t.Setenv("DB_CONN_MAX_IDLE_TIME", "0")
+	t.Setenv("DB_REPLICA_DSNS", "host=replica1 dbname=users, host=replica2 dbname=users,")
+	databaseConfig, err = config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 50, databaseConfig.MaxOpenConns)
+	assert.Zero(t, databaseConfig.MaxIdleConns)
+	assert.Equal(t, time.Hour, databaseConfig.ConnMaxLifetime)
+	assert.Zero(t, databaseConfig.ConnMaxIdleTime)
+	assert.Equal(t, []string{"host=replica1 dbname=users", "host=replica2 dbname=users"}, databaseConfig.ReplicaDSNs)
+}
+
+func TestNewDatabaseConfig_InvalidPool(t *testing.T) {
+	tests := map[string]map[string]string{
+		"open no numérico":      {"DB_MAX_OPEN_CONNS": "muchas"},
+		"open negativo":         {"DB_MAX_OPEN_CONNS": "-1"},
+		"idle negativo":         {"DB_MAX_IDLE_CONNS": "-1"},
+		"idle mayor que open":   {"DB_MAX_OPEN_CONNS": "5", "DB_MAX_IDLE_CONNS": "6"},
+		"lifetime negativo":     {"DB_CONN_MAX_LIFETIME": "-1m"},
+		"idle time no duración": {"DB_CONN_MAX_IDLE_TIME": "rato"},
+	}
+	for name, env := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := config.NewDatabaseConfig()
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+// setupReplicatedDB conecta a un primario y una réplica SQLite con el mismo esquema y
+// un registro distinto en cada uno, para saber de dónde lee cada consulta
+func setupReplicatedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dir := t.TempDir()
+	replica := config.DatabaseConfig{Driver: config.DriverSQLite, DBName: filepath.Join(dir, "replica.db")}
+	replicaDB, err := replica.Connect(context.Background())
+	require.NoError(t, err, "Error al abrir la réplica")
+	require.NoError(t, replicaDB.AutoMigrate(&timeoutRecord{}))
+	require.NoError(t, replicaDB.Create(&timeoutRecord{ID: 2}).Error)
+	sqlDB, _ := replicaDB.DB()
+	require.NoError(t, sqlDB.Close())
+
+	primary := config.DatabaseConfig{
+		Driver:       config.DriverSQLite,
+		DBName:       filepath.Join(dir, "primary.db"),
+		MaxOpenConns: 4,
+		ReplicaDSNs:  []string{replica.GetDSN()},
+	}
+	db, err := primary.Connect(context.Background())
+	require.NoError(t, err, "Error al conectar con réplicas")
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	require.NoError(t, db.AutoMigrate(&timeoutRecord{}))
+	require.NoError(t, db.Create(&timeoutRecord{ID: 1}).Error)
+	return db
+}
+
+func TestDatabaseConfig_Connect_RoutesReadsToReplicas(t *testing.T) {
+	// Arrange
+	db := setupReplicatedDB(t)
+	ctx := context.Background()
+	txManager := transaction.NewGormTxManager(db, 1)
+
+	// Act
+	var fromReplica, fromPrimary, inTx timeoutRecord
+	replicaErr := transaction.ReadDB(ctx, db).First(&fromReplica).Error
+	primaryErr := transaction.DB(ctx, db).First(&fromPrimary).Error
+	txErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return transaction.ReadDB(ctx, db).First(&inTx).Error
+	})
+
+	// Assert
+	require.NoError(t, replicaErr)
+	require.NoError(t, primaryErr)
+	require.NoError(t, txErr)
+	assert.Equal(t, uint(2), fromReplica.ID, "ReadDB debería leer de la réplica")
+	assert.Equal(t, uint(1), fromPrimary.ID, "Las consultas normales deberían seguir en el primario")
+	assert.Equal(t, uint(1), inTx.ID, "Dentro de una transacción debería leerse del primario")
+}
+
+func TestDatabaseConfig_Connect_ConfiguresPool(t *testing.T) {
+	db := setupReplicatedDB(t)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, sqlDB.Stats().MaxOpenConnections, "Debería aplicarse DB_MAX_OPEN_CONNS")
+}
+
+func TestNewDatabaseConfig_ConnectRetry(t *testing.T) {
+	databaseConfig, err := config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, databaseConfig.ConnectRetry.MaxWait)
+	assert.Equal(t, 500*time.Millisecond, databaseConfig.ConnectRetry.InitialBackoff)
+	assert.Equal(t, 10*time.Second, databaseConfig.ConnectRetry.MaxBackoff)
+	assert.False(t, databaseConfig.ConnectInBackground, "Por defecto el servidor debería esperar a la base de datos")
+
+	t.Setenv("DB_CONNECT_MAX_WAIT", "0")
+	t.Setenv("DB_CONNECT_IN_BACKGROUND", "true")
+	databaseConfig, err = config.NewDatabaseConfig()
+	require.NoError(t, err)
+	assert.Zero(t, databaseConfig.ConnectRetry.MaxWait, "0 debería hacer un único intento")
+	assert.True(t, databaseConfig.ConnectInBackground)
+}
+
+func TestNewDatabaseConfig_InvalidConnectRetry(t *testing.T) {
+	tests := map[string]map[string]string{
+		"espera negativa":          {"DB_CONNECT_MAX_WAIT": "-1s"},
+		"backoff inicial cero":     {"DB_CONNECT_INITIAL_BACKOFF": "0"},
+		"backoff máximo inválido":  {"DB_CONNECT_MAX_BACKOFF": "mucho"},
+		"máximo menor que inicial": {"DB_CONNECT_INITIAL_BACKOFF": "2s", "DB_CONNECT_MAX_BACKOFF": "1s"},
+		"intento negativo":         {"DB_CONNECT_ATTEMPT_TIMEOUT": "-1s"},
+	}
+	for name, env := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := config.NewDatabaseConfig()
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDatabaseConfig_ConnectWithRetry_WaitsForDatabase(t *testing.T) {
+	// Arrange: el directorio del fichero SQLite aparece después del primer intento
+	dir := filepath.Join(t.TempDir(), "datos")
+	databaseConfig := config.DatabaseConfig{
+		Driver:       config.DriverSQLite,
+		DBName:       filepath.Join(dir, "app.db"),
+		ConnectRetry: retry.Policy{InitialBackoff: 20 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, MaxWait: 5 * time.Second},
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = os.Mkdir(dir, 0o755)
+	}()
+
+	// Act
+	db, err := databaseConfig.ConnectWithRetry(context.Background())
+
+	// Assert
+	require.NoError(t, err, "Debería conectar en cuanto la base de datos esté disponible")
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.NoError(t, sqlDB.Close())
+}
+
+func TestDatabaseConfig_Connect_HonorsContext(t *testing.T) {
+	// Arrange
+	databaseConfig := config.DatabaseConfig{Driver: config.DriverSQLite, DBName: filepath.Join(t.TempDir(), "app.db")}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := databaseConfig.Connect(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled, "El ping de la conexión debería respetar el contexto")
+}
+
+func TestDatabaseConfig_ConnectWithRetry_GivesUp(t *testing.T) {
+	databaseConfig := config.DatabaseConfig{
+		Driver:       config.DriverSQLite,
+		DBName:       filepath.Join(t.TempDir(), "no-existe", "app.db"),
+		ConnectRetry: retry.Policy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 10 * time.Millisecond, MaxWait: 50 * time.Millisecond},
+	}
+
+	_, err := databaseConfig.ConnectWithRetry(context.Background())
+
+	assert.ErrorContains(t, err, "intentos fallidos", "Debería rendirse al agotar DB_CONNECT_MAX_WAIT")
+}
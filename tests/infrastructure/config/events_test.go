@@ -0,0 +1,49 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventsConfig_Defaults(t *testing.T) {
+	// Act
+	eventsConfig, err := config.NewEventsConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, config.EventPublisherBus, eventsConfig.Publisher)
+	assert.Equal(t, time.Second, eventsConfig.PollInterval)
+	assert.Equal(t, 100, eventsConfig.BatchSize)
+	assert.Equal(t, 10*time.Minute, eventsConfig.ClaimTimeout)
+}
+
+func TestNewEventsConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"publicador desconocido", map[string]string{"EVENTS_PUBLISHER": "kafka"}},
+		{"webhook sin URL", map[string]string{"EVENTS_PUBLISHER": "webhook"}},
+		{"intervalo no positivo", map[string]string{"OUTBOX_POLL_INTERVAL": "0s"}},
+		{"lote no numérico", map[string]string{"OUTBOX_BATCH_SIZE": "muchos"}},
+		{"lote no positivo", map[string]string{"OUTBOX_BATCH_SIZE": "0"}},
+		{"reserva no positiva", map[string]string{"OUTBOX_CLAIM_TIMEOUT": "0s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := config.NewEventsConfig()
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFlagSet() (*flag.FlagSet, *config.Flags) {
+	flagSet := flag.NewFlagSet("server", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	return flagSet, config.RegisterFlags(flagSet)
+}
+
+func TestFlagName(t *testing.T) {
+	assert.Equal(t, "db-max-open-conns", config.FlagName("DB_MAX_OPEN_CONNS"))
+}
+
+func TestFlags_Source(t *testing.T) {
+	// Arrange
+	file := writeFile(t, "config.yaml", "db:\n  host: file\n  user: file\n")
+	t.Setenv("DB_USER", "env")
+	flagSet, flags := newFlagSet()
+
+	// Act
+	require.NoError(t, flagSet.Parse([]string{"--config", file, "--db-host", "flag", "--print-config", "migrate", "up"}))
+	source, err := flags.Source()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, flags.PrintConfig)
+	assert.Equal(t, []string{"migrate", "up"}, flagSet.Args(), "los argumentos tras los flags son el subcomando")
+	assert.Equal(t, "flag", source.String("DB_HOST"))
+	assert.Equal(t, "env", source.String("DB_USER"))
+}
+
+func TestFlags_Source_DefaultEnvFileIsOptional(t *testing.T) {
+	// Arrange: el directorio de trabajo no tiene .env
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+	flagSet, flags := newFlagSet()
+	require.NoError(t, flagSet.Parse(nil))
+
+	// Act
+	_, err = flags.Source()
+
+	// Assert
+	assert.NoError(t, err, "el .env por defecto no es obligatorio")
+}
+
+func TestFlags_Source_ReadsEnvFileFromWorkingDirectory(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultEnvFile), []byte("DB_NAME=desde-env\n"), 0o600))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("DB_NAME", "")
+	flagSet, flags := newFlagSet()
+	require.NoError(t, flagSet.Parse(nil))
+
+	// Act
+	source, err := flags.Source()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "desde-env", source.String("DB_NAME"))
+}
+
+func TestFlags_Source_ExplicitEnvFileMustExist(t *testing.T) {
+	// Arrange
+	flagSet, flags := newFlagSet()
+	require.NoError(t, flagSet.Parse([]string{"--env-file", filepath.Join(t.TempDir(), "no-existe.env")}))
+
+	// Act
+	_, err := flags.Source()
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHealthConfig_Defaults(t *testing.T) {
+	// Act
+	healthConfig, err := config.NewHealthConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, healthConfig.CheckTimeout)
+	assert.Equal(t, 5*time.Second, healthConfig.CacheTTL)
+	assert.Equal(t, "/", healthConfig.DiskPath)
+	assert.Equal(t, uint64(100), healthConfig.DiskMinFreeMB)
+	assert.False(t, healthConfig.DBStatsEnabled, "/dbstats no debería publicarse por defecto")
+}
+
+func TestNewHealthConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{"timeout no es una duración", "HEALTH_CHECK_TIMEOUT", "rápido"},
+		{"timeout cero", "HEALTH_CHECK_TIMEOUT", "0s"},
+		{"caché negativa", "HEALTH_CACHE_TTL", "-1s"},
+		{"espacio mínimo no numérico", "HEALTH_DISK_MIN_FREE_MB", "mucho"},
+		{"dbstats no booleano", "HEALTH_DB_STATS_ENABLED", "quizás"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+
+			_, err := config.NewHealthConfig()
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const strongSecret = "0123456789abcdef0123456789abcdef"
+
+func validJWTConfig(environment, secret string) *config.JWTConfig {
+	return &config.JWTConfig{
+		Environment:      environment,
+		Secret:           secret,
+		Issuer:           "issuer",
+		Audience:         "api",
+		AccessTokenTTL:   15 * time.Minute,
+		RefreshTokenTTL:  24 * time.Hour,
+		SigningAlgorithm: "HS256",
+	}
+}
+
+func TestJWTConfig_Validate_Secret(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		secret      string
+		wantErr     bool
+	}{
+		{"producción sin secreto", "production", "", true},
+		{"entorno vacío sin secreto", "", "", true},
+		{"producción con secreto por defecto", "prod", "default-secret-key", true},
+		{"producción con secreto de ejemplo", "production", "your-secret-key", true},
+		{"producción con secreto corto", "production", "short-secret", true},
+		{"producción con secreto fuerte", "production", strongSecret, false},
+		{"desarrollo sin secreto", "dev", "", false},
+		{"desarrollo con secreto débil", "development", "secret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validJWTConfig(tt.environment, tt.secret).Validate()
+
+			// Assert
+			if tt.wantErr {
+				assert.Error(t, err, "La configuración debería ser rechazada")
+			} else {
+				assert.NoError(t, err, "La configuración debería ser aceptada")
+			}
+		})
+	}
+}
+
+func TestJWTConfig_Validate_ReportsEveryProblem(t *testing.T) {
+	// Arrange
+	cfg := validJWTConfig("production", "")
+	cfg.Issuer = ""
+	cfg.AccessTokenTTL = 0
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "JWT_SECRET_KEY")
+	assert.Contains(t, err.Error(), "JWT_ISSUER")
+	assert.Contains(t, err.Error(), "JWT_ACCESS_TOKEN_TTL")
+}
+
+func TestJWTConfig_Validate_AsymmetricRequiresKey(t *testing.T) {
+	// Arrange
+	cfg := validJWTConfig("production", "")
+	cfg.SigningAlgorithm = "RS256"
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	assert.ErrorContains(t, err, "JWT_PRIVATE_KEY_PATH", "Debería exigirse la clave privada")
+}
+
+func TestJWTConfig_NewTokenManager_DevelopmentGeneratesSecret(t *testing.T) {
+	// Arrange
+	cfg := validJWTConfig("dev", "")
+
+	// Act
+	tokens, err := cfg.NewTokenManager()
+
+	// Assert
+	require.NoError(t, err, "En desarrollo debería generarse un secreto temporal")
+	token, err := tokens.GenerateToken(auth.TokenSubject{UserID: "1", Email: "test@example.com"})
+	require.NoError(t, err)
+	claims, err := tokens.ValidateToken(token)
+	assert.NoError(t, err, "El token debería ser válido")
+	assert.Equal(t, "issuer", claims.Issuer, "El emisor no coincide")
+}
+
+func TestJWTConfig_NewTokenManager_ProductionRejectsWeakSecret(t *testing.T) {
+	// Act
+	_, err := validJWTConfig("production", "default-secret-key").NewTokenManager()
+
+	// Assert
+	assert.Error(t, err, "No debería poder firmarse con el secreto por defecto")
+}
+
+func TestNewJWTConfig_FromEnv(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV", "production")
+	t.Setenv("JWT_SECRET_KEY", strongSecret)
+	t.Setenv("JWT_ISSUER", "my-issuer")
+	t.Setenv("JWT_AUDIENCE", "my-api")
+	t.Setenv("JWT_ACCESS_TOKEN_TTL", "5m")
+	t.Setenv("JWT_LEEWAY", "10s")
+
+	// Act
+	cfg, err := config.NewJWTConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, strongSecret, cfg.Secret, "El secreto no coincide")
+	assert.Equal(t, "my-issuer", cfg.Issuer, "El emisor no coincide")
+	assert.Equal(t, "my-api", cfg.Audience, "La audiencia no coincide")
+	assert.Equal(t, 5*time.Minute, cfg.AccessTokenTTL, "La vigencia no coincide")
+	assert.Equal(t, 5*time.Minute, cfg.RotationWindow, "La ventana de rotación debería seguir a la vigencia del token")
+	assert.Equal(t, 10*time.Second, cfg.Leeway, "La tolerancia no coincide")
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestNewJWTConfig_ProductionRequiresIssuerAndAudience(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV", "production")
+	t.Setenv("JWT_SECRET_KEY", strongSecret)
+
+	// Act
+	cfg, err := config.NewJWTConfig()
+	require.NoError(t, err)
+	err = cfg.Validate()
+
+	// Assert
+	require.Error(t, err, "Fuera de desarrollo el emisor y la audiencia no tienen valor por defecto")
+	assert.Contains(t, err.Error(), "JWT_ISSUER")
+	assert.Contains(t, err.Error(), "JWT_AUDIENCE")
+}
+
+func TestNewJWTConfig_DevelopmentDefaultsIssuerAndAudience(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV", "development")
+
+	// Act
+	cfg, err := config.NewJWTConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "go-hexagonal-template", cfg.Issuer, "El emisor por defecto no coincide")
+	assert.Equal(t, "go-hexagonal-template-api", cfg.Audience, "La audiencia por defecto no coincide")
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestNewJWTConfig_InvalidDuration(t *testing.T) {
+	// Arrange
+	t.Setenv("JWT_ACCESS_TOKEN_TTL", "quince minutos")
+
+	// Act
+	_, err := config.NewJWTConfig()
+
+	// Assert
+	assert.ErrorContains(t, err, "JWT_ACCESS_TOKEN_TTL")
+}
@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServerConfig_Defaults(t *testing.T) {
+	// Act
+	serverConfig, err := config.NewServerConfig()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Second, serverConfig.ReadTimeout)
+	assert.Equal(t, 15*time.Second, serverConfig.WriteTimeout)
+	assert.Equal(t, 60*time.Second, serverConfig.IdleTimeout)
+	assert.Equal(t, 5*time.Second, serverConfig.DrainPeriod)
+	assert.Equal(t, 30*time.Second, serverConfig.ShutdownTimeout)
+}
+
+func TestNewServerConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"timeout no es una duración", map[string]string{"SERVER_READ_TIMEOUT": "lento"}},
+		{"timeout negativo", map[string]string{"SERVER_IDLE_TIMEOUT": "-1s"}},
+		{"drenaje mayor que el apagado", map[string]string{"SHUTDOWN_DRAIN_PERIOD": "10s", "SHUTDOWN_TIMEOUT": "5s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := config.NewServerConfig()
+
+			assert.Error(t, err)
+		})
+	}
+}
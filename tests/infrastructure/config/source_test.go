@@ -0,0 +1,169 @@
+package config_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewSource_Precedence(t *testing.T) {
+	// Arrange: cada nivel define una clave más que el siguiente
+	file := writeFile(t, "config.yaml", `
+db:
+  host: file
+  port: "5433"
+  user: file
+  name: file
+`)
+	envFile := writeFile(t, ".env", "DB_HOST=envfile\nDB_PORT=envfile\nDB_USER=envfile\n")
+	t.Setenv("DB_HOST", "env")
+	t.Setenv("DB_PORT", "env")
+
+	// Act
+	source, err := config.NewSource(config.SourceOptions{
+		File:    file,
+		EnvFile: envFile,
+		Flags:   map[string]string{"DB_HOST": "flag"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "flag", source.String("DB_HOST"), "los flags tienen prioridad sobre el entorno")
+	assert.Equal(t, "env", source.String("DB_PORT"), "el entorno tiene prioridad sobre el .env")
+	assert.Equal(t, "envfile", source.String("DB_USER"), "el .env tiene prioridad sobre el fichero")
+	assert.Equal(t, "file", source.String("DB_NAME"), "el fichero tiene prioridad sobre el valor por defecto")
+	assert.Equal(t, "postgres", source.String("DB_DRIVER"), "sin valor se usa el de por defecto")
+	assert.NoError(t, source.Err())
+}
+
+func TestNewSource_EnvFileDoesNotChangeEnvironment(t *testing.T) {
+	// Arrange
+	envFile := writeFile(t, ".env", "DB_USER=envfile\n")
+	t.Setenv("DB_USER", "")
+
+	// Act
+	source, err := config.NewSource(config.SourceOptions{EnvFile: envFile})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "envfile", source.String("DB_USER"), "una variable vacía se considera ausente")
+	assert.Empty(t, os.Getenv("DB_USER"), "el .env no debe modificar el entorno del proceso")
+}
+
+func TestNewSource_TOML(t *testing.T) {
+	// Arrange
+	file := writeFile(t, "config.toml", `
+port = 8080
+
+[db]
+replica_dsns = ["replica1", "replica2"]
+query_timeout = "2s"
+`)
+
+	// Act
+	source, err := config.NewSource(config.SourceOptions{File: file})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "8080", source.String("PORT"))
+	assert.Equal(t, []string{"replica1", "replica2"}, source.List("DB_REPLICA_DSNS"))
+	assert.Equal(t, 2*time.Second, source.Duration("DB_QUERY_TIMEOUT"))
+}
+
+func TestNewSource_ConfigFileFromEnv(t *testing.T) {
+	// Arrange
+	t.Setenv("CONFIG_FILE", writeFile(t, "config.yml", "env: production\n"))
+
+	// Act
+	source, err := config.NewSource(config.SourceOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "production", source.String("ENV"))
+}
+
+func TestNewSource_UnknownKeys(t *testing.T) {
+	// Arrange
+	file := writeFile(t, "config.yaml", "db:\n  hots: localhost\nporth: 3000\n")
+
+	// Act
+	source, err := config.NewSource(config.SourceOptions{File: file})
+
+	// Assert
+	require.NoError(t, err)
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(source.Err(), &validationErr))
+	require.Len(t, validationErr.Problems, 2, "debe informar de cada clave desconocida")
+	assert.Contains(t, validationErr.Problems[0], `"db.hots"`)
+	assert.Contains(t, validationErr.Problems[1], `"porth"`)
+}
+
+func TestNewSource_ReadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options config.SourceOptions
+	}{
+		{"fichero inexistente", config.SourceOptions{File: filepath.Join(t.TempDir(), "config.yaml")}},
+		{"formato no soportado", config.SourceOptions{File: writeFile(t, "config.json", "{}")}},
+		{"yaml mal formado", config.SourceOptions{File: writeFile(t, "config.yaml", "db: [")}},
+		{".env inexistente", config.SourceOptions{EnvFile: filepath.Join(t.TempDir(), ".env")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.NewSource(tt.options)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSource_InvalidValueFallsBackToDefault(t *testing.T) {
+	// Arrange
+	source, err := config.NewSource(config.SourceOptions{Flags: map[string]string{"DB_MAX_OPEN_CONNS": "muchas"}})
+	require.NoError(t, err)
+
+	// Act
+	value := source.Int("DB_MAX_OPEN_CONNS")
+
+	// Assert
+	assert.Equal(t, 25, value, "un valor inválido debe sustituirse por el de por defecto")
+	require.Error(t, source.Err())
+	assert.Contains(t, source.Err().Error(), `DB_MAX_OPEN_CONNS inválido "muchas" (flag)`)
+}
+
+func TestSource_Print_RedactsSecrets(t *testing.T) {
+	// Arrange
+	t.Setenv("DB_PASSWORD", "supersecreta")
+	t.Setenv("JWT_SECRET_KEY", "")
+	source, err := config.NewSource(config.SourceOptions{Flags: map[string]string{"PORT": "8080"}})
+	require.NoError(t, err)
+	var out bytes.Buffer
+
+	// Act
+	err = source.Print(&out)
+
+	// Assert
+	require.NoError(t, err)
+	printed := out.String()
+	assert.NotContains(t, printed, "supersecreta", "los secretos no deben mostrarse")
+	assert.Regexp(t, `(?m)^DB_PASSWORD=\*+ +# env$`, printed)
+	assert.Regexp(t, `(?m)^JWT_SECRET_KEY= +# default$`, printed, "un secreto vacío se muestra vacío")
+	assert.Regexp(t, `(?m)^PORT=8080 +# flag$`, printed)
+	assert.Regexp(t, `(?m)^DB_DRIVER=postgres +# default$`, printed)
+}
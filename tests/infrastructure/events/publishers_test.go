@@ -0,0 +1,104 @@
+package events_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/events"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleMessage() sharedmodel.EventMessage {
+	return sharedmodel.EventMessage{
+		ID:         7,
+		Name:       "user.registered",
+		Payload:    json.RawMessage(`{"user_id":1}`),
+		OccurredAt: time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestBus_Publish_DeliversToSubscribers(t *testing.T) {
+	// Arrange
+	bus := events.NewBus()
+	var received []string
+	bus.Subscribe("user.registered", func(ctx context.Context, message sharedmodel.EventMessage) error {
+		received = append(received, "primero")
+		return errors.New("fallo")
+	})
+	bus.Subscribe("user.registered", func(ctx context.Context, message sharedmodel.EventMessage) error {
+		received = append(received, "segundo")
+		return nil
+	})
+	bus.Subscribe("user.logged_in", func(ctx context.Context, message sharedmodel.EventMessage) error {
+		received = append(received, "otro evento")
+		return nil
+	})
+
+	// Act
+	err := bus.Publish(context.Background(), sampleMessage())
+
+	// Assert
+	assert.ErrorContains(t, err, "fallo", "El error de un suscriptor debería provocar el reintento")
+	assert.Equal(t, []string{"primero", "segundo"}, received, "Todos los suscriptores del evento deberían ejecutarse")
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "aceptado", status: http.StatusAccepted},
+		{name: "error del destino", status: http.StatusInternalServerError, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var received *http.Request
+			var body sharedmodel.EventMessage
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			publisher := events.NewWebhookPublisher(server.URL, time.Second)
+
+			// Act
+			err := publisher.Publish(context.Background(), sampleMessage())
+
+			// Assert
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NotNil(t, received)
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, "7", received.Header.Get("X-Event-ID"))
+			assert.Equal(t, "user.registered", received.Header.Get("X-Event-Name"))
+			assert.Equal(t, sampleMessage(), body)
+		})
+	}
+}
+
+func TestWriterPublisher_Publish_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	publisher := events.NewWriterPublisher(&buf)
+
+	require.NoError(t, publisher.Publish(context.Background(), sampleMessage()))
+	require.NoError(t, publisher.Publish(context.Background(), sampleMessage()))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2, "Cada evento debería escribirse en su propia línea")
+	assert.JSONEq(t, `{"id":7,"name":"user.registered","payload":{"user_id":1},"occurred_at":"2026-10-17T09:30:00Z"}`, string(lines[0]))
+}
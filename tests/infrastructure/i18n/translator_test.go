@@ -0,0 +1,78 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTranslator_DefaultsToSpanish(t *testing.T) {
+	// Act
+	translator, err := i18n.NewTranslator("")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "es", translator.DefaultLocale(), "El idioma por defecto debería ser español")
+}
+
+func TestNewTranslator_UnsupportedLocale(t *testing.T) {
+	// Act
+	_, err := i18n.NewTranslator("fr")
+
+	// Assert
+	assert.Error(t, err, "Un idioma sin catálogo debería rechazarse")
+}
+
+func TestTranslator_Negotiate(t *testing.T) {
+	translator, err := i18n.NewTranslator("es")
+	require.NoError(t, err)
+
+	cases := map[string]string{
+		"":                        "es",
+		"en":                      "en",
+		"en-US,en;q=0.9":          "en",
+		"es-MX":                   "es",
+		"fr-FR,en;q=0.8,es;q=0.5": "en",
+		"de-DE":                   "es",
+		"%%invalid%%":             "es",
+	}
+	for header, expected := range cases {
+		assert.Equal(t, expected, translator.Negotiate(header), "Accept-Language %q debería negociar %q", header, expected)
+	}
+}
+
+func TestTranslator_ConfigurableDefault(t *testing.T) {
+	// Arrange
+	translator, err := i18n.NewTranslator("en")
+	require.NoError(t, err)
+
+	// Act
+	locale := translator.Negotiate("de-DE")
+
+	// Assert
+	assert.Equal(t, "en", locale, "Un idioma no soportado debería usar el idioma por defecto configurado")
+	assert.Equal(t, "User not found", translator.Message(locale, "USER_NOT_FOUND"))
+}
+
+func TestTranslator_Translate(t *testing.T) {
+	translator, err := i18n.NewTranslator("es")
+	require.NoError(t, err)
+
+	// Un idioma desconocido usa el catálogo por defecto
+	message, ok := translator.Translate("fr", "INVALID_CREDENTIALS")
+	assert.True(t, ok)
+	assert.Equal(t, "Credenciales inválidas", message)
+
+	// Los argumentos se aplican al mensaje
+	message, ok = translator.Translate("en", "validation.min", "6")
+	assert.True(t, ok)
+	assert.Equal(t, "must be at least 6 characters long", message)
+
+	// Las claves inexistentes se informan y Message devuelve la propia clave
+	_, ok = translator.Translate("en", "UNKNOWN_CODE")
+	assert.False(t, ok)
+	assert.Equal(t, "UNKNOWN_CODE", translator.Message("en", "UNKNOWN_CODE"))
+}
@@ -0,0 +1,173 @@
+package migrations_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_SortsByVersionAndComputesChecksum(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"2_add_index.up.sql":   {Data: []byte("CREATE INDEX a ON t (c);")},
+		"2_add_index.down.sql": {Data: []byte("DROP INDEX a;")},
+		"1_init.up.sql":        {Data: []byte("CREATE TABLE t (c INT);")},
+		"1_init.down.sql":      {Data: []byte("DROP TABLE t;")},
+		"README.md":            {Data: []byte("ignorado")},
+	}
+
+	// Act
+	list, err := migrations.Load(fsys)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, int64(1), list[0].Version)
+	assert.Equal(t, "init", list[0].Name)
+	assert.Equal(t, "DROP TABLE t;", list[0].Down)
+	assert.Equal(t, int64(2), list[1].Version)
+	assert.Len(t, list[0].Checksum, 64, "El checksum debería ser un SHA-256 en hexadecimal")
+	assert.NotEqual(t, list[0].Checksum, list[1].Checksum)
+}
+
+func TestLoad_RequiresUpAndDown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"1_init.up.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+	}
+
+	_, err := migrations.Load(fsys)
+
+	assert.ErrorIs(t, err, migrations.ErrIncompleteMigration)
+}
+
+func TestLoad_RejectsDuplicateVersions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"1_init.up.sql":    {Data: []byte("SELECT 1;")},
+		"1_init.down.sql":  {Data: []byte("SELECT 1;")},
+		"1_other.up.sql":   {Data: []byte("SELECT 2;")},
+		"1_other.down.sql": {Data: []byte("SELECT 2;")},
+	}
+
+	_, err := migrations.Load(fsys)
+
+	assert.ErrorIs(t, err, migrations.ErrDuplicateVersion)
+}
+
+func TestLoadFor_PrefersDriverSpecificScripts(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"1_init.up.sql":        {Data: []byte("CREATE TABLE t (id BIGSERIAL);")},
+		"1_init.sqlite.up.sql": {Data: []byte("CREATE TABLE t (id INTEGER PRIMARY KEY);")},
+		"1_init.mysql.up.sql":  {Data: []byte("CREATE TABLE t (id BIGINT AUTO_INCREMENT);")},
+		"1_init.down.sql":      {Data: []byte("DROP TABLE t;")},
+	}
+
+	// Act
+	sqliteList, err := migrations.LoadFor(migrations.DriverSQLite, fsys)
+	require.NoError(t, err)
+	postgresList, err := migrations.LoadFor(migrations.DriverPostgres, fsys)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, sqliteList, 1)
+	assert.Equal(t, "CREATE TABLE t (id INTEGER PRIMARY KEY);", sqliteList[0].Up, "Debería usar el script del motor")
+	assert.Equal(t, "DROP TABLE t;", sqliteList[0].Down, "Sin script del motor debería usar el genérico")
+	require.Len(t, postgresList, 1)
+	assert.Equal(t, "CREATE TABLE t (id BIGSERIAL);", postgresList[0].Up, "Sin script de Postgres debería usar el genérico")
+	assert.NotEqual(t, sqliteList[0].Checksum, postgresList[0].Checksum, "El checksum debería ser el del script usado")
+}
+
+func TestLoad_IgnoresDriverSpecificScripts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"1_init.up.sql":         {Data: []byte("SELECT 1;")},
+		"1_init.down.sql":       {Data: []byte("SELECT 1;")},
+		"2_next.mysql.up.sql":   {Data: []byte("SELECT 2;")},
+		"2_next.mysql.down.sql": {Data: []byte("SELECT 2;")},
+	}
+
+	list, err := migrations.Load(fsys)
+
+	require.NoError(t, err)
+	require.Len(t, list, 1, "Las migraciones exclusivas de otro motor no deberían cargarse")
+	assert.Equal(t, int64(1), list[0].Version)
+}
+
+func TestLoadAll_MergesSourcesInVersionOrder(t *testing.T) {
+	// Arrange
+	users := fstest.MapFS{
+		"1_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT);")},
+		"1_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"3_roles.up.sql":   {Data: []byte("CREATE TABLE roles (id INT);")},
+		"3_roles.down.sql": {Data: []byte("DROP TABLE roles;")},
+	}
+	products := fstest.MapFS{
+		"2_products.up.sql":   {Data: []byte("CREATE TABLE products (id INT);")},
+		"2_products.down.sql": {Data: []byte("DROP TABLE products;")},
+	}
+
+	// Act
+	list, err := migrations.LoadAll(users, nil, products)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, []string{"users", "products", "roles"}, []string{list[0].Name, list[1].Name, list[2].Name})
+}
+
+func TestLoadAll_RejectsVersionsSharedBetweenSources(t *testing.T) {
+	first := fstest.MapFS{
+		"1_users.up.sql":   {Data: []byte("SELECT 1;")},
+		"1_users.down.sql": {Data: []byte("SELECT 1;")},
+	}
+	second := fstest.MapFS{
+		"1_products.up.sql":   {Data: []byte("SELECT 2;")},
+		"1_products.down.sql": {Data: []byte("SELECT 2;")},
+	}
+
+	_, err := migrations.LoadAll(first, second)
+
+	assert.ErrorIs(t, err, migrations.ErrDuplicateVersion)
+}
+
+func TestCreate_WritesUpAndDownFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	// Act
+	upPath, downPath, err := migrations.Create(dir, "Add Users Index", now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20260304050607_add_users_index.up.sql"), upPath)
+	assert.Equal(t, filepath.Join(dir, "20260304050607_add_users_index.down.sql"), downPath)
+
+	list, err := migrations.Load(os.DirFS(dir))
+	require.NoError(t, err, "Los ficheros creados deberían poder cargarse")
+	require.Len(t, list, 1)
+	assert.Equal(t, int64(20260304050607), list[0].Version)
+}
+
+func TestCreate_DoesNotOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	_, _, err := migrations.Create(dir, "init", now)
+	require.NoError(t, err)
+
+	_, _, err = migrations.Create(dir, "init", now)
+
+	assert.ErrorIs(t, err, os.ErrExist, "No debería sobrescribir una migración existente")
+}
+
+func TestCreate_InvalidName(t *testing.T) {
+	_, _, err := migrations.Create(t.TempDir(), " -- ", time.Now())
+
+	assert.ErrorIs(t, err, migrations.ErrInvalidName)
+}
@@ -0,0 +1,230 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore guarda las migraciones aplicadas en memoria y registra el uso del bloqueo
+type fakeStore struct {
+	lock      sync.Mutex
+	applied   map[int64]migrations.AppliedMigration
+	locked    bool
+	unlocks   int
+	failApply int64
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{applied: make(map[int64]migrations.AppliedMigration)}
+}
+
+func (s *fakeStore) Lock(ctx context.Context) error {
+	s.lock.Lock()
+	s.locked = true
+	return nil
+}
+
+func (s *fakeStore) Unlock(ctx context.Context) error {
+	s.locked = false
+	s.unlocks++
+	s.lock.Unlock()
+	return nil
+}
+
+func (s *fakeStore) EnsureTable(ctx context.Context) error {
+	return nil
+}
+
+func (s *fakeStore) Applied(ctx context.Context) ([]migrations.AppliedMigration, error) {
+	list := make([]migrations.AppliedMigration, 0, len(s.applied))
+	for _, record := range s.applied {
+		list = append(list, record)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+func (s *fakeStore) Apply(ctx context.Context, migration migrations.Migration) error {
+	if !s.locked {
+		return errors.New("aplicada sin bloqueo")
+	}
+	if migration.Version == s.failApply {
+		return errors.New("error de sintaxis")
+	}
+	s.applied[migration.Version] = migrations.AppliedMigration{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Checksum:  migration.Checksum,
+		AppliedAt: time.Now(),
+	}
+	return nil
+}
+
+func (s *fakeStore) Revert(ctx context.Context, migration migrations.Migration) error {
+	if !s.locked {
+		return errors.New("revertida sin bloqueo")
+	}
+	delete(s.applied, migration.Version)
+	return nil
+}
+
+func sampleMigrations() []migrations.Migration {
+	return []migrations.Migration{
+		{Version: 1, Name: "init", Up: "up 1", Down: "down 1", Checksum: "c1"},
+		{Version: 2, Name: "add_index", Up: "up 2", Down: "down 2", Checksum: "c2"},
+		{Version: 3, Name: "add_column", Up: "up 3", Down: "down 3", Checksum: "c3"},
+	}
+}
+
+func TestMigrator_Up_AppliesPendingInOrder(t *testing.T) {
+	// Arrange
+	store := newFakeStore()
+	store.applied[1] = migrations.AppliedMigration{Version: 1, Name: "init", Checksum: "c1"}
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+
+	// Act
+	applied, err := migrator.Up(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, int64(2), applied[0].Version)
+	assert.Equal(t, int64(3), applied[1].Version)
+	assert.Len(t, store.applied, 3)
+	assert.Equal(t, 1, store.unlocks, "El bloqueo debería liberarse al terminar")
+}
+
+func TestMigrator_Up_IsIdempotent(t *testing.T) {
+	store := newFakeStore()
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, applied, "No debería volver a aplicar migraciones")
+}
+
+func TestMigrator_Up_ChecksumMismatch(t *testing.T) {
+	// Arrange
+	store := newFakeStore()
+	store.applied[1] = migrations.AppliedMigration{Version: 1, Name: "init", Checksum: "otro"}
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+
+	// Act
+	applied, err := migrator.Up(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, migrations.ErrChecksumMismatch)
+	assert.Empty(t, applied)
+	assert.Len(t, store.applied, 1, "No debería aplicar nada si una migración fue modificada")
+	assert.Equal(t, 1, store.unlocks, "El bloqueo debería liberarse aunque falle")
+}
+
+func TestMigrator_Up_StopsAtFailedMigration(t *testing.T) {
+	store := newFakeStore()
+	store.failApply = 2
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.Error(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, int64(1), applied[0].Version)
+	assert.NotContains(t, store.applied, int64(3), "No debería continuar tras un fallo")
+}
+
+func TestMigrator_Up_SerializesConcurrentRuns(t *testing.T) {
+	// Arrange
+	store := newFakeStore()
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+
+	// Act
+	var wg sync.WaitGroup
+	counts := make([]int, 5)
+	for i := range counts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			applied, err := migrator.Up(context.Background())
+			assert.NoError(t, err)
+			counts[i] = len(applied)
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	assert.Equal(t, 3, total, "Cada migración debería aplicarse una sola vez")
+}
+
+func TestMigrator_Down_RevertsLatest(t *testing.T) {
+	// Arrange
+	store := newFakeStore()
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	// Act
+	reverted, err := migrator.Down(context.Background(), 2)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, reverted, 2)
+	assert.Equal(t, int64(3), reverted[0].Version)
+	assert.Equal(t, int64(2), reverted[1].Version)
+	assert.Len(t, store.applied, 1)
+}
+
+func TestMigrator_Down_MissingMigration(t *testing.T) {
+	store := newFakeStore()
+	store.applied[9] = migrations.AppliedMigration{Version: 9, Name: "future", Checksum: "c9"}
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+
+	_, err := migrator.Down(context.Background(), 1)
+
+	assert.ErrorIs(t, err, migrations.ErrMissingMigration)
+}
+
+func TestMigrator_Down_InvalidSteps(t *testing.T) {
+	migrator := migrations.NewMigrator(newFakeStore(), sampleMigrations())
+
+	_, err := migrator.Down(context.Background(), 0)
+
+	assert.ErrorIs(t, err, migrations.ErrInvalidSteps)
+}
+
+func TestMigrator_Status(t *testing.T) {
+	// Arrange
+	store := newFakeStore()
+	store.applied[1] = migrations.AppliedMigration{Version: 1, Name: "init", Checksum: "c1"}
+	store.applied[2] = migrations.AppliedMigration{Version: 2, Name: "add_index", Checksum: "editada"}
+	store.applied[9] = migrations.AppliedMigration{Version: 9, Name: "future", Checksum: "c9"}
+	migrator := migrations.NewMigrator(store, sampleMigrations())
+
+	// Act
+	statuses, err := migrator.Status(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].Modified)
+	assert.True(t, statuses[1].Modified, "Debería detectar el checksum distinto")
+	assert.False(t, statuses[2].Applied, "La versión 3 debería estar pendiente")
+	assert.Nil(t, statuses[2].AppliedAt)
+	assert.True(t, statuses[3].Missing, "La versión 9 no existe en el binario")
+}
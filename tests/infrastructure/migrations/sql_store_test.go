@@ -0,0 +1,87 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"go-hexagonal-template/internal/infrastructure/migrations"
+
+	_ "github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openSQLite abre una base de datos SQLite en un fichero temporal
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrations.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func sqliteSources() fstest.MapFS {
+	return fstest.MapFS{
+		"1_init.up.sql":        {Data: []byte("CREATE TABLE t (id BIGSERIAL PRIMARY KEY);")},
+		"1_init.sqlite.up.sql": {Data: []byte("CREATE TABLE t (id INTEGER PRIMARY KEY); CREATE INDEX idx_t_id ON t (id);")},
+		"1_init.down.sql":      {Data: []byte("DROP TABLE t;")},
+		"2_fail.up.sql":        {Data: []byte("CREATE TABLE u (id INTEGER); INSERT INTO missing VALUES (1);")},
+		"2_fail.down.sql":      {Data: []byte("DROP TABLE u;")},
+	}
+}
+
+func TestSQLStore_SQLiteAppliesAndRevertsDriverScripts(t *testing.T) {
+	// Arrange
+	db := openSQLite(t)
+	fsys := sqliteSources()
+	delete(fsys, "2_fail.up.sql")
+	delete(fsys, "2_fail.down.sql")
+	migrator, err := migrations.NewSQLMigrator(db, migrations.DriverSQLite, fsys)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	statuses, statusErr := migrator.Status(ctx)
+	reverted, downErr := migrator.Down(ctx, 1)
+
+	// Assert
+	require.Len(t, applied, 1)
+	assert.Contains(t, applied[0].Up, "INTEGER PRIMARY KEY", "Debería aplicarse el script de SQLite")
+	require.NoError(t, statusErr)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Applied)
+	assert.NotNil(t, statuses[0].AppliedAt, "Debería registrarse la fecha de aplicación")
+	require.NoError(t, downErr)
+	assert.Len(t, reverted, 1)
+	var tables int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 't'").Scan(&tables))
+	assert.Zero(t, tables, "El script down debería haberse ejecutado")
+}
+
+func TestSQLStore_SQLiteRollsBackFailedMigration(t *testing.T) {
+	// Arrange
+	db := openSQLite(t)
+	migrator, err := migrations.NewSQLMigrator(db, migrations.DriverSQLite, sqliteSources())
+	require.NoError(t, err)
+
+	// Act
+	applied, err := migrator.Up(context.Background())
+
+	// Assert
+	assert.Error(t, err, "La migración fallida debería informarse")
+	assert.Len(t, applied, 1, "Solo la primera migración debería aplicarse")
+	var tables int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'u'").Scan(&tables))
+	assert.Zero(t, tables, "Las sentencias de la migración fallida deberían revertirse")
+}
+
+func TestNewSQLStore_RejectsUnknownDriver(t *testing.T) {
+	_, err := migrations.NewSQLStore(nil, "oracle")
+
+	assert.ErrorIs(t, err, migrations.ErrUnsupportedDriver)
+}
@@ -0,0 +1,151 @@
+package module_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"go-hexagonal-template/internal/infrastructure/module"
+	healthapplication "go-hexagonal-template/internal/modules/health/application"
+	"go-hexagonal-template/internal/modules/health/domain/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeModule registra una ruta pública y otra protegida con su nombre
+type fakeModule struct {
+	name       string
+	migrations fs.FS
+	initErr    error
+	initOrder  *[]string
+	checks     []port.Checker
+	hooks      []module.ShutdownHook
+}
+
+func (m *fakeModule) Name() string      { return m.name }
+func (m *fakeModule) Migrations() fs.FS { return m.migrations }
+
+func (m *fakeModule) Init(ctx context.Context, deps module.Dependencies) error {
+	if m.initOrder != nil {
+		*m.initOrder = append(*m.initOrder, m.name)
+	}
+	return m.initErr
+}
+
+func (m *fakeModule) RegisterRoutes(routes module.Routes) {
+	routes.Public.GET("/"+m.name, func(c *gin.Context) { c.Status(http.StatusOK) })
+	routes.Protected.GET("/"+m.name, func(c *gin.Context) { c.Status(http.StatusOK) })
+}
+
+func (m *fakeModule) HealthChecks() []port.Checker         { return m.checks }
+func (m *fakeModule) ShutdownHooks() []module.ShutdownHook { return m.hooks }
+
+// authModule acepta solo las peticiones con la cabecera Authorization
+type authModule struct {
+	fakeModule
+}
+
+func (m *authModule) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+func TestNewRegistry_RejectsDuplicateNames(t *testing.T) {
+	_, err := module.NewRegistry(&fakeModule{name: "user"}, &fakeModule{name: "user"})
+
+	assert.ErrorIs(t, err, module.ErrDuplicateModule)
+}
+
+func TestRegistry_Init_RunsInOrderAndStopsOnError(t *testing.T) {
+	// Arrange
+	var order []string
+	registry, err := module.NewRegistry(
+		&fakeModule{name: "user", initOrder: &order},
+		&fakeModule{name: "product", initOrder: &order, initErr: errors.New("sin conexión")},
+		&fakeModule{name: "order", initOrder: &order},
+	)
+	require.NoError(t, err)
+
+	// Act
+	err = registry.Init(context.Background(), module.Dependencies{})
+
+	// Assert
+	assert.ErrorContains(t, err, "product")
+	assert.Equal(t, []string{"user", "product"}, order, "No debería inicializarse ningún módulo después del que falla")
+}
+
+func TestRegistry_RegisterRoutes_ProtectsAPIWithAuthenticator(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registry, err := module.NewRegistry(&authModule{fakeModule{name: "user"}}, &fakeModule{name: "product"})
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, registry.RegisterRoutes(router))
+
+	// Assert
+	tests := []struct {
+		path   string
+		token  string
+		status int
+	}{
+		{path: "/product", status: http.StatusOK},
+		{path: "/api/product", status: http.StatusUnauthorized},
+		{path: "/api/product", token: "Bearer x", status: http.StatusOK},
+		{path: "/api/user", status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", tt.token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.status, w.Code, "Código inesperado para %s", tt.path)
+	}
+}
+
+func TestRegistry_RegisterRoutes_RequiresOneAuthenticator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry, err := module.NewRegistry(&fakeModule{name: "product"})
+	require.NoError(t, err)
+
+	err = registry.RegisterRoutes(gin.New())
+
+	assert.ErrorIs(t, err, module.ErrAuthenticator)
+}
+
+func TestRegistry_CollectsMigrationsChecksAndHooks(t *testing.T) {
+	// Arrange
+	migrations := fstest.MapFS{}
+	check := healthapplication.NewChecker("cache", func(ctx context.Context) error { return nil })
+	hook := module.ShutdownHook{Name: "flush", Fn: func(ctx context.Context) error { return nil }}
+	registry, err := module.NewRegistry(
+		&fakeModule{name: "user", migrations: migrations},
+		&fakeModule{name: "product", checks: []port.Checker{check}, hooks: []module.ShutdownHook{hook}},
+	)
+	require.NoError(t, err)
+
+	// Act
+	sources := registry.Migrations()
+	checks := registry.HealthChecks()
+	hooks := registry.ShutdownHooks()
+
+	// Assert
+	assert.Len(t, sources, 1, "Los módulos sin migraciones no deberían aportar orígenes")
+	assert.Equal(t, []port.Checker{check}, checks)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "product/flush", hooks[0].Name)
+}
@@ -0,0 +1,45 @@
+package outbox_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/migrations"
+	"go-hexagonal-template/internal/infrastructure/outbox"
+	userpersistence "go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_CreatesOutboxTable(t *testing.T) {
+	// Se cargan junto a las del módulo de usuarios para detectar versiones repetidas
+	list, err := migrations.LoadAll(userpersistence.Migrations(), outbox.Migrations())
+
+	require.NoError(t, err, "Las migraciones incluidas en el binario deberían ser válidas")
+	migration := findMigration(t, list, "create_outbox_events")
+	assert.Contains(t, migration.Up, "CREATE TABLE IF NOT EXISTS outbox_events")
+}
+
+func TestMigrations_HaveScriptsForEachDriver(t *testing.T) {
+	for _, driver := range []string{migrations.DriverPostgres, migrations.DriverMySQL, migrations.DriverSQLite} {
+		t.Run(driver, func(t *testing.T) {
+			list, err := migrations.LoadAllFor(driver, userpersistence.Migrations(), outbox.Migrations())
+
+			require.NoError(t, err, "Las migraciones del motor deberían ser válidas")
+			migration := findMigration(t, list, "create_outbox_events")
+			assert.Contains(t, migration.Up, "CREATE TABLE IF NOT EXISTS outbox_events")
+		})
+	}
+}
+
+// findMigration devuelve la migración con el nombre dado o hace fallar el test
+func findMigration(t *testing.T, list []migrations.Migration, name string) migrations.Migration {
+	t.Helper()
+	for _, migration := range list {
+		if migration.Name == name {
+			return migration
+		}
+	}
+	require.Failf(t, "migración no encontrada", "no se cargó la migración %s", name)
+	return migrations.Migration{}
+}
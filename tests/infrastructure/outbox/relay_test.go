@@ -0,0 +1,232 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/infrastructure/outbox"
+	"go-hexagonal-template/internal/infrastructure/transaction"
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordingPublisher guarda los eventos publicados y falla mientras failures sea positivo
+type recordingPublisher struct {
+	published []sharedmodel.EventMessage
+	failures  int
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, message sharedmodel.EventMessage) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("destino no disponible")
+	}
+	p.published = append(p.published, message)
+	return nil
+}
+
+// setupDB crea una base de datos SQLite en un fichero temporal y le aplica la migración
+// del outbox para ese motor
+func setupDB(t *testing.T) *gorm.DB {
+	databaseConfig := &config.DatabaseConfig{
+		Driver: config.DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "outbox.db"),
+	}
+	db, err := databaseConfig.Connect(context.Background())
+	require.NoError(t, err, "Error al abrir la base de datos")
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	require.NoError(t, databaseConfig.Migrate(context.Background(), db, outbox.Migrations()))
+	return db
+}
+
+func records(t *testing.T, db *gorm.DB) []outbox.Record {
+	var result []outbox.Record
+	require.NoError(t, db.Order("id").Find(&result).Error)
+	return result
+}
+
+func TestGormStore_Add_JoinsTransaction(t *testing.T) {
+	// Arrange
+	db := setupDB(t)
+	store := outbox.NewGormStore(db)
+	txManager := transaction.NewGormTxManager(db, 1)
+	ctx := context.Background()
+
+	// Act
+	commitErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return store.Add(ctx, model.UserRegistered{UserID: 1, Email: "ana@example.com"})
+	})
+	rollbackErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := store.Add(ctx, model.PasswordChanged{UserID: 1}); err != nil {
+			return err
+		}
+		return errors.New("revertir")
+	})
+
+	// Assert
+	require.NoError(t, commitErr)
+	require.Error(t, rollbackErr)
+	stored := records(t, db)
+	require.Len(t, stored, 1, "El evento de la transacción revertida no debería guardarse")
+	assert.Equal(t, model.EventUserRegistered, stored[0].Name)
+	assert.JSONEq(t, `{"user_id":1,"email":"ana@example.com"}`, stored[0].Payload)
+	assert.Nil(t, stored[0].PublishedAt, "El evento debería quedar pendiente")
+}
+
+func TestRelay_ProcessBatch_PublishesPendingEvents(t *testing.T) {
+	// Arrange
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, outbox.NewGormStore(db).Add(ctx, model.UserRegistered{UserID: 1}, model.UserLoggedIn{UserID: 1, SessionID: "s1"}))
+	publisher := &recordingPublisher{}
+	relay := outbox.NewRelay(db, publisher, time.Second, 10, time.Minute)
+
+	// Act
+	processed, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	again, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, processed)
+	assert.Zero(t, again, "Los eventos publicados no deberían publicarse de nuevo")
+	require.Len(t, publisher.published, 2)
+	assert.Equal(t, model.EventUserRegistered, publisher.published[0].Name)
+	assert.Equal(t, model.EventUserLoggedIn, publisher.published[1].Name)
+	assert.NotZero(t, publisher.published[0].ID, "El mensaje debería llevar el ID para deduplicar")
+	for _, record := range records(t, db) {
+		assert.NotNil(t, record.PublishedAt, "El evento %d debería marcarse como publicado", record.ID)
+	}
+}
+
+func TestRelay_ProcessBatch_RetriesFailedEvents(t *testing.T) {
+	// Arrange
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, outbox.NewGormStore(db).Add(ctx, model.PasswordChanged{UserID: 1}))
+	publisher := &recordingPublisher{failures: 1}
+	relay := outbox.NewRelay(db, publisher, time.Second, 10, time.Minute)
+
+	// Act
+	_, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	stored := records(t, db)[0]
+	assert.Nil(t, stored.PublishedAt, "El evento fallido debería seguir pendiente")
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, "destino no disponible", stored.LastError)
+	assert.True(t, stored.NextAttemptAt.After(time.Now()), "El reintento debería programarse en el futuro")
+
+	processed, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "El evento no debería reintentarse antes de su plazo")
+
+	// Act: vence el plazo de reintento
+	require.NoError(t, db.Model(&outbox.Record{}).Where("id = ?", stored.ID).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	processed, err = relay.ProcessBatch(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Len(t, publisher.published, 1, "El evento debería entregarse en el reintento")
+	assert.NotNil(t, records(t, db)[0].PublishedAt)
+}
+
+// funcPublisher publica llamando a fn
+type funcPublisher func(ctx context.Context, message sharedmodel.EventMessage) error
+
+func (f funcPublisher) Publish(ctx context.Context, message sharedmodel.EventMessage) error {
+	return f(ctx, message)
+}
+
+func TestRelay_ProcessBatch_PublishesOutsideTransaction(t *testing.T) {
+	// Arrange
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, outbox.NewGormStore(db).Add(ctx, model.UserRegistered{UserID: 1}, model.PasswordChanged{UserID: 1}))
+	other := outbox.NewRelay(db, &recordingPublisher{}, time.Second, 10, time.Minute)
+	var claimedByOther []int
+	var published []uint
+	relay := outbox.NewRelay(db, funcPublisher(func(ctx context.Context, message sharedmodel.EventMessage) error {
+		// Mientras se publica no hay transacción abierta y el lote sigue reservado
+		processed, err := other.ProcessBatch(ctx)
+		if err != nil {
+			return err
+		}
+		claimedByOther = append(claimedByOther, processed)
+		published = append(published, message.ID)
+		return nil
+	}), time.Second, 10, time.Minute)
+
+	// Act
+	processed, err := relay.ProcessBatch(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []int{0, 0}, claimedByOther, "Otro relay no debería tomar los eventos reservados")
+	assert.Len(t, published, 2)
+	stored := records(t, db)
+	assert.NotNil(t, stored[0].PublishedAt, "El primer evento debería marcarse en cuanto se entrega")
+	assert.NotNil(t, stored[1].PublishedAt)
+}
+
+func TestRelay_ProcessBatch_ReleasesUnpublishedEventsWhenClaimExpires(t *testing.T) {
+	// Arrange: la reserva vence mientras se publica el primer evento
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, outbox.NewGormStore(db).Add(ctx, model.UserRegistered{UserID: 1}, model.PasswordChanged{UserID: 1}))
+	slow := outbox.NewRelay(db, funcPublisher(func(ctx context.Context, message sharedmodel.EventMessage) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}), time.Second, 10, 10*time.Millisecond)
+	publisher := &recordingPublisher{}
+	other := outbox.NewRelay(db, publisher, time.Second, 10, time.Minute)
+
+	// Act
+	processed, err := slow.ProcessBatch(ctx)
+	require.NoError(t, err)
+	recovered, err := other.ProcessBatch(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, processed, "Tras vencer la reserva no debería publicar el resto del lote")
+	assert.Equal(t, 1, recovered, "El evento no publicado debería quedar pendiente para otro relay")
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, model.EventPasswordChanged, publisher.published[0].Name)
+}
+
+func TestRelay_Run_StopsWithContext(t *testing.T) {
+	db := setupDB(t)
+	require.NoError(t, outbox.NewGormStore(db).Add(context.Background(), model.UserRegistered{UserID: 1}))
+	publisher := &recordingPublisher{}
+	relay := outbox.NewRelay(db, publisher, 10*time.Millisecond, 10, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+	require.Eventually(t, func() bool { return records(t, db)[0].PublishedAt != nil }, time.Second, 10*time.Millisecond, "El relay debería publicar el evento pendiente")
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("El relay debería detenerse al cancelar el contexto")
+	}
+}
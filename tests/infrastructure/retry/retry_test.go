@@ -0,0 +1,105 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/retry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errUnavailable = errors.New("conexión rechazada")
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := retry.Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{20, time.Second},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			backoff := policy.Backoff(tt.attempt)
+			assert.GreaterOrEqual(t, backoff, tt.max/2, "El jitter no debería bajar de la mitad en el intento %d", tt.attempt)
+			assert.LessOrEqual(t, backoff, tt.max, "La espera no debería superar el máximo en el intento %d", tt.attempt)
+		}
+	}
+}
+
+func TestDo_RetriesUntilSuccess(t *testing.T) {
+	// Arrange
+	policy := retry.Policy{InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxWait: time.Second}
+	calls := 0
+	var retried []int
+
+	// Act
+	err := retry.Do(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errUnavailable
+		}
+		return nil
+	}, func(attempt int, err error, wait time.Duration) {
+		assert.ErrorIs(t, err, errUnavailable)
+		retried = append(retried, attempt)
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, retried, "Debería notificarse cada intento fallido")
+}
+
+func TestDo_GivesUpAfterMaxWait(t *testing.T) {
+	// Arrange
+	policy := retry.Policy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 10 * time.Millisecond, MaxWait: 50 * time.Millisecond}
+	start := time.Now()
+
+	// Act
+	err := retry.Do(context.Background(), policy, func(ctx context.Context) error {
+		return errUnavailable
+	}, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, errUnavailable, "Debería devolverse el último error")
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "No debería esperarse mucho más allá de MaxWait")
+}
+
+func TestDo_ZeroMaxWaitTriesOnce(t *testing.T) {
+	calls := 0
+
+	err := retry.Do(context.Background(), retry.Policy{InitialBackoff: time.Second}, func(ctx context.Context) error {
+		calls++
+		return errUnavailable
+	}, nil)
+
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, 1, calls)
+}
+
+func TestDo_StopsWhenContextIsCancelled(t *testing.T) {
+	// Arrange
+	policy := retry.Policy{InitialBackoff: time.Minute, MaxBackoff: time.Minute, MaxWait: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	err := retry.Do(ctx, policy, func(ctx context.Context) error {
+		return errUnavailable
+	}, func(attempt int, err error, wait time.Duration) {
+		cancel()
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errUnavailable)
+}
@@ -0,0 +1,197 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer arranca el servidor en un puerto libre y devuelve su URL y el resultado de Serve
+func startServer(t *testing.T, srv *server.Server, ctx context.Context) (string, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, listener)
+	}()
+	return "http://" + listener.Addr().String(), done
+}
+
+func TestServer_DrainsInFlightRequests(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	})
+	srv := server.New(handler, server.Options{DrainPeriod: 10 * time.Millisecond, ShutdownTimeout: 2 * time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	url, done := startServer(t, srv, ctx)
+
+	var drained bool
+	srv.OnDrain(func() { drained = true })
+
+	// Act
+	responses := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err == nil {
+			responses <- resp
+		}
+		close(responses)
+	}()
+	<-started
+	cancel()
+
+	// Assert
+	require.NoError(t, <-done, "El apagado debería terminar sin errores")
+	assert.True(t, drained, "Los hooks de drenaje deberían ejecutarse")
+
+	resp, ok := <-responses
+	require.True(t, ok, "La petición en curso debería completarse")
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+}
+
+func TestServer_RunsShutdownHooksInReverseOrder(t *testing.T) {
+	// Arrange
+	srv := server.New(http.NotFoundHandler(), server.Options{ShutdownTimeout: time.Second})
+	var mu sync.Mutex
+	var order []string
+	for _, name := range []string{"database", "workers"} {
+		name := name
+		srv.OnShutdown(name, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	_, done := startServer(t, srv, ctx)
+
+	// Act
+	cancel()
+
+	// Assert
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"workers", "database"}, order, "Los hooks deberían ejecutarse en orden inverso al registro")
+}
+
+func TestServer_ReportsHookErrors(t *testing.T) {
+	// Arrange
+	srv := server.New(http.NotFoundHandler(), server.Options{ShutdownTimeout: time.Second})
+	hookErr := errors.New("no se pudo cerrar")
+	srv.OnShutdown("database", func(ctx context.Context) error { return hookErr })
+	var workersStopped bool
+	srv.OnShutdown("workers", func(ctx context.Context) error {
+		workersStopped = true
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	_, done := startServer(t, srv, ctx)
+
+	// Act
+	cancel()
+	err := <-done
+
+	// Assert
+	assert.ErrorIs(t, err, hookErr, "El error del hook debería propagarse")
+	assert.True(t, workersStopped, "Un hook fallido no debería impedir que se ejecuten los demás")
+}
+
+func TestServer_WaitsForBackgroundTasksBeforeHooks(t *testing.T) {
+	// Arrange
+	srv := server.New(http.NotFoundHandler(), server.Options{ShutdownTimeout: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	var closed bool
+	srv.Go(func() {
+		// Simula un arranque que registra su hook después de recibir la señal
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		srv.OnShutdown("database", func(ctx context.Context) error {
+			closed = true
+			return nil
+		})
+	})
+	_, done := startServer(t, srv, ctx)
+
+	// Act
+	cancel()
+
+	// Assert
+	require.NoError(t, <-done)
+	assert.True(t, closed, "Los hooks registrados durante el arranque deberían ejecutarse")
+}
+
+func TestServer_HardDeadline(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	srv := server.New(handler, server.Options{ShutdownTimeout: 100 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	url, done := startServer(t, srv, ctx)
+
+	go func() {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	// Act
+	start := time.Now()
+	cancel()
+	err := <-done
+
+	// Assert
+	assert.Error(t, err, "Superar el plazo debería informarse como error")
+	assert.Less(t, time.Since(start), time.Second, "El apagado no debería esperar más que el plazo máximo")
+}
+
+func TestServer_Run_InvalidAddress(t *testing.T) {
+	srv := server.New(http.NotFoundHandler(), server.Options{Addr: "dirección-inválida", ShutdownTimeout: time.Second})
+
+	assert.Error(t, srv.Run(context.Background()))
+}
+
+func TestSwitchHandler_Switch(t *testing.T) {
+	// Arrange
+	respond := func(body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, body)
+		})
+	}
+	handler := server.NewSwitchHandler(respond("arrancando"))
+	get := func() string {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Body.String()
+	}
+
+	// Act & Assert
+	assert.Equal(t, "arrancando", get())
+	handler.Switch(respond("lista"))
+	assert.Equal(t, "lista", get(), "Las peticiones siguientes deberían usar el nuevo handler")
+}
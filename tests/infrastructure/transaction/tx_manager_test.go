@@ -0,0 +1,172 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/transaction"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type account struct {
+	ID   uint
+	Name string
+}
+
+var errRollback = errors.New("revertir")
+
+// setupDB crea una base de datos SQLite en un fichero temporal con la tabla de prueba
+func setupDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tx.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err, "Error al abrir la base de datos")
+	require.NoError(t, db.AutoMigrate(&account{}))
+	return db
+}
+
+func createAccount(ctx context.Context, db *gorm.DB, name string) error {
+	return transaction.DB(ctx, db).Create(&account{Name: name}).Error
+}
+
+func accountNames(t *testing.T, db *gorm.DB) []string {
+	var names []string
+	require.NoError(t, db.Model(&account{}).Order("id").Pluck("name", &names).Error)
+	return names
+}
+
+func TestGormTxManager_WithinTx_CommitsAndRollsBack(t *testing.T) {
+	// Arrange
+	db := setupDB(t)
+	txManager := transaction.NewGormTxManager(db, transaction.DefaultMaxAttempts)
+	ctx := context.Background()
+
+	// Act
+	commitErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := createAccount(ctx, db, "ana"); err != nil {
+			return err
+		}
+		return createAccount(ctx, db, "luis")
+	})
+	rollbackErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := createAccount(ctx, db, "eva"); err != nil {
+			return err
+		}
+		return errRollback
+	})
+
+	// Assert
+	require.NoError(t, commitErr)
+	assert.ErrorIs(t, rollbackErr, errRollback)
+	assert.Equal(t, []string{"ana", "luis"}, accountNames(t, db), "Solo deberían persistir los cambios de la transacción confirmada")
+}
+
+func TestGormTxManager_WithinTx_NestedUsesSavepoint(t *testing.T) {
+	// Arrange
+	db := setupDB(t)
+	txManager := transaction.NewGormTxManager(db, transaction.DefaultMaxAttempts)
+
+	// Act
+	var nestedErr error
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := createAccount(ctx, db, "ana"); err != nil {
+			return err
+		}
+		nestedErr = txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := createAccount(ctx, db, "eva"); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		return createAccount(ctx, db, "luis")
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, nestedErr, errRollback)
+	assert.Equal(t, []string{"ana", "luis"}, accountNames(t, db), "El fallo anidado solo debería revertir su savepoint")
+}
+
+func TestGormTxManager_WithinTx_RetriesSerializationFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		failure      error
+		wantAttempts int
+		wantNames    []string
+		wantErr      bool
+	}{
+		{name: "serialización", failures: 1, failure: &pgconn.PgError{Code: "40001"}, wantAttempts: 2, wantNames: []string{"ana"}},
+		{name: "interbloqueo", failures: 2, failure: &pgconn.PgError{Code: "40P01"}, wantAttempts: 3, wantNames: []string{"ana"}},
+		{name: "intentos agotados", failures: 3, failure: &pgconn.PgError{Code: "40001"}, wantAttempts: 3, wantErr: true},
+		{name: "error no reintentable", failures: 1, failure: errRollback, wantAttempts: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := setupDB(t)
+			txManager := transaction.NewGormTxManager(db, 3)
+
+			// Act
+			attempts := 0
+			err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+				attempts++
+				if err := createAccount(ctx, db, "ana"); err != nil {
+					return err
+				}
+				if attempts <= tt.failures {
+					return tt.failure
+				}
+				return nil
+			})
+
+			// Assert
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.failure)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts, "Número de intentos inesperado")
+			names := accountNames(t, db)
+			if tt.wantNames == nil {
+				assert.Empty(t, names, "Los intentos fallidos no deberían dejar cambios")
+			} else {
+				assert.Equal(t, tt.wantNames, names, "Los intentos fallidos no deberían dejar cambios")
+			}
+		})
+	}
+}
+
+func TestGormTxManager_WithinTx_StopsRetryingWhenContextEnds(t *testing.T) {
+	db := setupDB(t)
+	txManager := transaction.NewGormTxManager(db, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		attempts++
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts, "No debería reintentarse con el contexto cancelado")
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, transaction.IsRetryable(&pgconn.PgError{Code: "40001"}))
+	assert.True(t, transaction.IsRetryable(&pgconn.PgError{Code: "40P01"}))
+	assert.False(t, transaction.IsRetryable(&pgconn.PgError{Code: "23505"}))
+	assert.True(t, transaction.IsRetryable(&mysql.MySQLError{Number: 1213}))
+	assert.False(t, transaction.IsRetryable(&mysql.MySQLError{Number: 1062}))
+	assert.False(t, transaction.IsRetryable(errRollback))
+}
@@ -0,0 +1,132 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/i18n"
+	"go-hexagonal-template/internal/middleware"
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupErrorTestRouter(err error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(translator))
+	router.GET("/resource", func(c *gin.Context) {
+		_ = c.Error(err)
+	})
+	return router
+}
+
+func serveProblem(t *testing.T, router *gin.Engine, acceptLanguage string) (*httptest.ResponseRecorder, middleware.Problem) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/resource", nil)
+	req.Header.Set("Accept-Language", acceptLanguage)
+	router.ServeHTTP(w, req)
+
+	var problem middleware.Problem
+	err := json.Unmarshal(w.Body.Bytes(), &problem)
+	assert.NoError(t, err, "Error al deserializar el problema")
+	return w, problem
+}
+
+func TestErrorHandler_MapsKindToStatus(t *testing.T) {
+	cases := map[apperror.Kind]int{
+		apperror.KindNotFound:     http.StatusNotFound,
+		apperror.KindConflict:     http.StatusConflict,
+		apperror.KindValidation:   http.StatusBadRequest,
+		apperror.KindUnauthorized: http.StatusUnauthorized,
+		apperror.KindForbidden:    http.StatusForbidden,
+		apperror.KindRateLimited:  http.StatusTooManyRequests,
+		apperror.KindInternal:     http.StatusInternalServerError,
+	}
+	for kind, status := range cases {
+		// Arrange
+		router := setupErrorTestRouter(apperror.New(kind, "SOME_CODE", "algo falló"))
+
+		// Act
+		w, problem := serveProblem(t, router, "")
+
+		// Assert
+		assert.Equal(t, status, w.Code, "El tipo %s debería responder %d", kind, status)
+		assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"), "El tipo de contenido debería ser problem+json")
+		assert.Equal(t, status, problem.Status, "El estado del problema no coincide")
+		assert.Equal(t, "/problems/some-code", problem.Type, "El tipo del problema no coincide")
+		assert.Equal(t, "/resource", problem.Instance, "La instancia debería ser la ruta de la petición")
+		assert.NotEmpty(t, problem.Title, "El título no debería estar vacío")
+	}
+}
+
+func TestErrorHandler_UnknownErrorHidesDetails(t *testing.T) {
+	// Arrange
+	router := setupErrorTestRouter(errors.New("pq: password authentication failed"))
+
+	// Act
+	w, problem := serveProblem(t, router, "")
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "Un error desconocido debería responder 500")
+	assert.Equal(t, apperror.CodeInternal, problem.Code, "El código no coincide")
+	assert.NotContains(t, problem.Detail, "password", "El detalle no debería exponer el error original")
+}
+
+func TestErrorHandler_IncludesFieldErrors(t *testing.T) {
+	// Arrange
+	router := setupErrorTestRouter(apperror.Validation("INVALID_SETTINGS", "datos inválidos",
+		apperror.FieldError{Field: "email", Code: "email", Message: "debe ser un email válido"},
+	))
+
+	// Act
+	w, problem := serveProblem(t, router, "")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Un error de validación debería responder 400")
+	assert.Equal(t, "datos inválidos", problem.Detail, "El detalle no coincide")
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Code: "email", Message: "debe ser un email válido"},
+	}, problem.Errors, "Los errores por campo no coinciden")
+}
+
+func TestErrorHandler_TranslatesCatalogCodes(t *testing.T) {
+	// Arrange
+	router := setupErrorTestRouter(apperror.NotFound("USER_NOT_FOUND", "usuario no encontrado"))
+
+	// Act
+	_, spanish := serveProblem(t, router, "es-ES")
+	_, english := serveProblem(t, router, "en-US,en;q=0.9")
+	_, fallback := serveProblem(t, router, "fr-FR")
+
+	// Assert
+	assert.Equal(t, "Usuario no encontrado", spanish.Detail, "El detalle debería estar en español")
+	assert.Equal(t, "User not found", english.Detail, "El detalle debería estar en inglés")
+	assert.Equal(t, "Resource not found", english.Title, "El título debería estar en inglés")
+	assert.Equal(t, "Usuario no encontrado", fallback.Detail, "Un idioma no soportado debería usar el idioma por defecto")
+}
+
+func TestErrorHandler_IgnoresWrittenResponses(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	translator, _ := i18n.NewTranslator(i18n.DefaultLocale)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(translator))
+	router.GET("/resource", func(c *gin.Context) {
+		_ = c.Error(errors.New("error ya gestionado"))
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/resource", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code, "No debería reemplazar una respuesta ya escrita")
+}
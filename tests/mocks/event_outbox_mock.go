@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+)
+
+// EventOutboxMock guarda en memoria los eventos añadidos para testing
+type EventOutboxMock struct {
+	mu     sync.Mutex
+	events []sharedmodel.Event
+}
+
+func NewEventOutboxMock() *EventOutboxMock {
+	return &EventOutboxMock{}
+}
+
+func (m *EventOutboxMock) Add(ctx context.Context, events ...sharedmodel.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, events...)
+	return nil
+}
+
+// Events retorna los eventos añadidos en orden
+func (m *EventOutboxMock) Events() []sharedmodel.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]sharedmodel.Event(nil), m.events...)
+}
@@ -0,0 +1,86 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-hexagonal-template/internal/modules/user/domain/model"
+)
+
+// RefreshTokenRepositoryMock es un repositorio en memoria de refresh tokens para testing
+type RefreshTokenRepositoryMock struct {
+	mu     sync.Mutex
+	nextID uint
+	tokens map[uint]*model.RefreshToken
+}
+
+func NewRefreshTokenRepositoryMock() *RefreshTokenRepositoryMock {
+	return &RefreshTokenRepositoryMock{
+		tokens: make(map[uint]*model.RefreshToken),
+	}
+}
+
+func (m *RefreshTokenRepositoryMock) Create(ctx context.Context, token *model.RefreshToken) (*model.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	token.ID = m.nextID
+	stored := *token
+	m.tokens[token.ID] = &stored
+	return token, nil
+}
+
+func (m *RefreshTokenRepositoryMock) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.tokens {
+		if token.TokenHash == hash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, model.ErrRefreshTokenNotFound
+}
+
+func (m *RefreshTokenRepositoryMock) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[id]
+	if !ok || token.UsedAt != nil {
+		return false, nil
+	}
+	token.UsedAt = &usedAt
+	return true, nil
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeAllForUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+// Expire fuerza el vencimiento de todos los tokens almacenados
+func (m *RefreshTokenRepositoryMock) Expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.tokens {
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+}
@@ -0,0 +1,88 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"go-hexagonal-template/internal/modules/user/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RoleRepositoryMock es un repositorio en memoria de roles para testing
+type RoleRepositoryMock struct {
+	mu        sync.Mutex
+	nextID    uint
+	roles     map[string]*model.Role
+	userRoles map[uint][]string
+}
+
+// NewRoleRepositoryMock crea el repositorio con los roles por defecto ya registrados
+func NewRoleRepositoryMock() *RoleRepositoryMock {
+	m := &RoleRepositoryMock{
+		roles:     make(map[string]*model.Role),
+		userRoles: make(map[uint][]string),
+	}
+	for _, role := range model.DefaultRoles() {
+		_, _ = m.Save(context.Background(), &role)
+	}
+	return m
+}
+
+func (m *RoleRepositoryMock) Save(ctx context.Context, role *model.Role) (*model.Role, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.roles[role.Name]; ok {
+		stored.Permissions = role.Permissions
+		*role = *stored
+		return role, nil
+	}
+	m.nextID++
+	role.ID = m.nextID
+	stored := *role
+	m.roles[role.Name] = &stored
+	return role, nil
+}
+
+func (m *RoleRepositoryMock) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	role, ok := m.roles[name]
+	if !ok {
+		return nil, assert.AnError
+	}
+	found := *role
+	return &found, nil
+}
+
+func (m *RoleRepositoryMock) GetByUserID(ctx context.Context, userID uint) ([]model.Role, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	roles := []model.Role{}
+	for _, name := range m.userRoles[userID] {
+		roles = append(roles, *m.roles[name])
+	}
+	return roles, nil
+}
+
+func (m *RoleRepositoryMock) AssignToUser(ctx context.Context, userID uint, roleName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.roles[roleName]; !ok {
+		return assert.AnError
+	}
+	for _, name := range m.userRoles[userID] {
+		if name == roleName {
+			return nil
+		}
+	}
+	m.userRoles[userID] = append(m.userRoles[userID], roleName)
+	return nil
+}
+
+// RoleNames retorna los nombres de los roles asignados a un usuario
+func (m *RoleRepositoryMock) RoleNames(userID uint) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.userRoles[userID]...)
+}
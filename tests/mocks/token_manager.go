@@ -0,0 +1,12 @@
+package mocks
+
+import "go-hexagonal-template/internal/infrastructure/auth"
+
+// NewTokenManager crea un TokenManager HS256 con una clave fija para testing
+func NewTokenManager() *auth.TokenManager {
+	keyManager, err := auth.NewKeyManager(auth.NewHMACKey("test", []byte("test-secret-key-with-at-least-32-bytes")))
+	if err != nil {
+		panic(err)
+	}
+	return auth.NewTokenManager(keyManager, auth.TokenOptions{})
+}
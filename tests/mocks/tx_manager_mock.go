@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+)
+
+// txMarker marca los contextos que recibe fn para que los tests sepan qué se ejecutó en la transacción
+type txMarker struct{}
+
+// TxManagerMock ejecuta fn directamente, sin transacción, y cuenta las llamadas para testing
+type TxManagerMock struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func NewTxManagerMock() *TxManagerMock {
+	return &TxManagerMock{}
+}
+
+func (m *TxManagerMock) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	m.calls++
+	m.mu.Unlock()
+	return fn(context.WithValue(ctx, txMarker{}, true))
+}
+
+// Calls retorna cuántas veces se abrió una transacción
+func (m *TxManagerMock) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+// InTx indica si ctx es el de una función ejecutada por TxManagerMock.WithinTx
+func InTx(ctx context.Context) bool {
+	inTx, _ := ctx.Value(txMarker{}).(bool)
+	return inTx
+}
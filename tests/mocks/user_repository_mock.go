@@ -1,59 +1,117 @@
 package mocks
 
 import (
-	"time"
+	"context"
+	"sync"
 
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
 	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
 
-	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// UserRepositoryMock es un mock del repositorio para testing
-type UserRepositoryMock struct{}
+// TestUserPassword es la contraseña de los usuarios que crea NewUserRepositoryMock
+const TestUserPassword = "password123"
 
+// UserRepositoryMock envuelve el repositorio en memoria para testing y permite simular
+// fallos de la base de datos con Fail
+type UserRepositoryMock struct {
+	port.UserRepository
+	mu  sync.Mutex
+	err error
+}
+
+// NewUserRepositoryMock crea el repositorio con test@example.com (ID 1, "Test") y
+// other@example.com (ID 2, "Other"), ambos con la contraseña TestUserPassword
 func NewUserRepositoryMock() *UserRepositoryMock {
-	return &UserRepositoryMock{}
-}
-
-func (m *UserRepositoryMock) Create(user *model.User) (*model.User, error) {
-	now := time.Now()
-	if user.ID == 0 {
-		user.ID = 1 // ID fijo para testing
-	}
-	user.CreatedAt = now
-	user.UpdatedAt = now
-	return user, nil
-}
-
-func (m *UserRepositoryMock) GetByID(id uint) (*model.User, error) {
-	if id == 9999 {
-		return nil, assert.AnError
-	}
-	now := time.Now()
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
-	return &model.User{
-		ID:        id,
-		Email:     "test@example.com",
-		Name:      "Test",
-		Password:  string(hashedPassword),
-		CreatedAt: now,
-		UpdatedAt: now,
-	}, nil
-}
-
-func (m *UserRepositoryMock) GetByEmail(email string) (*model.User, error) {
-	if email == "nonexistent@example.com" {
-		return nil, assert.AnError
-	}
-	now := time.Now()
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
-	return &model.User{
-		ID:        1,
-		Email:     email,
-		Name:      "Test",
-		Password:  string(hashedPassword),
-		CreatedAt: now,
-		UpdatedAt: now,
-	}, nil
+	repository := memory.NewUserRepository()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(TestUserPassword), bcrypt.MinCost)
+	for _, user := range []model.User{
+		{Email: "test@example.com", Name: "Test"},
+		{Email: "other@example.com", Name: "Other"},
+	} {
+		user.Password = string(hashedPassword)
+		if _, err := repository.Create(context.Background(), &user); err != nil {
+			panic(err)
+		}
+	}
+	return &UserRepositoryMock{UserRepository: repository}
+}
+
+// Fail hace que todas las llamadas siguientes retornen err; nil vuelve al funcionamiento normal
+func (m *UserRepositoryMock) Fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+func (m *UserRepositoryMock) failure() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+func (m *UserRepositoryMock) Create(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.Create(ctx, user)
+}
+
+func (m *UserRepositoryMock) GetByID(ctx context.Context, id uint) (*model.User, error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.GetByID(ctx, id)
+}
+
+func (m *UserRepositoryMock) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.GetByEmail(ctx, email)
+}
+
+func (m *UserRepositoryMock) GetByEmailIncludingDeleted(ctx context.Context, email string) (*model.User, error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.GetByEmailIncludingDeleted(ctx, email)
+}
+
+func (m *UserRepositoryMock) List(ctx context.Context, query model.UserQuery) (*sharedmodel.Page[model.User], error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.List(ctx, query)
+}
+
+func (m *UserRepositoryMock) Update(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.Update(ctx, user)
+}
+
+func (m *UserRepositoryMock) Delete(ctx context.Context, id uint) error {
+	if err := m.failure(); err != nil {
+		return err
+	}
+	return m.UserRepository.Delete(ctx, id)
+}
+
+func (m *UserRepositoryMock) Restore(ctx context.Context, id uint) (*model.User, error) {
+	if err := m.failure(); err != nil {
+		return nil, err
+	}
+	return m.UserRepository.Restore(ctx, id)
+}
+
+func (m *UserRepositoryMock) HardDelete(ctx context.Context, id uint) error {
+	if err := m.failure(); err != nil {
+		return err
+	}
+	return m.UserRepository.HardDelete(ctx, id)
 }
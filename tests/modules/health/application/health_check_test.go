@@ -11,7 +11,7 @@ import (
 
 func TestHealthCheckUseCase_Execute(t *testing.T) {
 	// Arrange
-	useCase := application.NewHealthCheckUseCase()
+	useCase := application.NewHealthCheckUseCase(application.NewDrainState())
 	expectedHealth := &model.Health{
 		Status:  "UP",
 		Message: "¡Healthy!",
@@ -24,3 +24,17 @@ func TestHealthCheckUseCase_Execute(t *testing.T) {
 	assert.Equal(t, expectedHealth.Status, health.Status)
 	assert.Equal(t, expectedHealth.Message, health.Message)
 }
+
+func TestHealthCheckUseCase_Execute_Draining(t *testing.T) {
+	// Arrange
+	drain := application.NewDrainState()
+	useCase := application.NewHealthCheckUseCase(drain)
+
+	// Act
+	drain.Start()
+	health := useCase.Execute()
+
+	// Assert
+	assert.Equal(t, model.StatusDown, health.Status)
+	assert.False(t, health.IsUp())
+}
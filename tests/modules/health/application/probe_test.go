@@ -0,0 +1,124 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/modules/health/application"
+	"go-hexagonal-template/internal/modules/health/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeUseCase_Execute_AllUp(t *testing.T) {
+	// Arrange
+	useCase := application.NewProbeUseCase(time.Second, 0)
+	useCase.Register(application.NewChecker("database", func(ctx context.Context) error { return nil }), 0)
+	useCase.Register(application.NewChecker("disk", func(ctx context.Context) error { return nil }), 0)
+
+	// Act
+	health := useCase.Execute(context.Background())
+
+	// Assert
+	assert.True(t, health.IsUp(), "La sonda debería estar operativa")
+	require.Len(t, health.Checks, 2)
+	assert.Equal(t, "database", health.Checks[0].Name)
+	assert.Equal(t, model.StatusUp, health.Checks[0].Status)
+	assert.GreaterOrEqual(t, health.Checks[0].LatencyMs, 0.0)
+	assert.NotNil(t, health.CheckedAt)
+}
+
+func TestProbeUseCase_Execute_FailingCheck(t *testing.T) {
+	// Arrange
+	useCase := application.NewProbeUseCase(time.Second, 0)
+	useCase.Register(application.NewChecker("database", func(ctx context.Context) error {
+		return errors.New("conexión rechazada")
+	}), 0)
+
+	// Act
+	health := useCase.Execute(context.Background())
+
+	// Assert
+	assert.Equal(t, model.StatusDown, health.Status, "Un chequeo caído debería marcar la sonda como caída")
+	assert.Equal(t, model.StatusDown, health.Checks[0].Status)
+	assert.Equal(t, "conexión rechazada", health.Checks[0].Error)
+}
+
+func TestProbeUseCase_Execute_PerCheckTimeout(t *testing.T) {
+	// Arrange
+	useCase := application.NewProbeUseCase(time.Second, 0)
+	release := make(chan struct{})
+	defer close(release)
+	// Este chequeo ignora el contexto y debe abandonarse al vencer su plazo
+	useCase.Register(application.NewChecker("slow", func(ctx context.Context) error {
+		<-release
+		return nil
+	}), 20*time.Millisecond)
+	useCase.Register(application.NewChecker("fast", func(ctx context.Context) error { return nil }), 0)
+
+	// Act
+	start := time.Now()
+	health := useCase.Execute(context.Background())
+
+	// Assert
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "La sonda no debería esperar más que el plazo del chequeo")
+	assert.Equal(t, model.StatusDown, health.Status)
+	assert.Equal(t, model.StatusDown, health.Checks[0].Status)
+	assert.NotEmpty(t, health.Checks[0].Error)
+	assert.Equal(t, model.StatusUp, health.Checks[1].Status)
+}
+
+func TestProbeUseCase_Execute_CachesResult(t *testing.T) {
+	// Arrange
+	var calls int32
+	useCase := application.NewProbeUseCase(time.Second, time.Minute)
+	useCase.Register(application.NewChecker("database", func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}), 0)
+
+	// Act
+	first := useCase.Execute(context.Background())
+	second := useCase.Execute(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "El segundo chequeo debería salir de la caché")
+	assert.Same(t, first, second)
+}
+
+func TestProbeUseCase_Execute_IgnoresCallerCancellation(t *testing.T) {
+	// Arrange
+	useCase := application.NewProbeUseCase(time.Second, time.Minute)
+	useCase.Register(application.NewChecker("database", func(ctx context.Context) error {
+		return ctx.Err()
+	}), 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	health := useCase.Execute(ctx)
+
+	// Assert
+	assert.True(t, health.IsUp(), "Una petición cancelada no debería cachear la sonda como caída")
+}
+
+func TestProbeUseCase_Execute_Draining(t *testing.T) {
+	// Arrange
+	drain := application.NewDrainState()
+	useCase := application.NewProbeUseCase(time.Second, time.Minute)
+	useCase.Register(application.NewChecker("database", func(ctx context.Context) error { return nil }), 0)
+	useCase.WatchDrain(drain)
+	require.True(t, useCase.Execute(context.Background()).IsUp())
+
+	// Act
+	drain.Start()
+	health := useCase.Execute(context.Background())
+
+	// Assert
+	assert.Equal(t, model.StatusDown, health.Status, "El drenaje debería ignorar el resultado cacheado")
+	assert.Equal(t, "shutdown", health.Checks[0].Name)
+}
@@ -0,0 +1,36 @@
+package checks_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go-hexagonal-template/internal/modules/health/infrastructure/checks"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDatabasePoolStats_PoolStats(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "stats.db")), &gorm.Config{})
+	require.NoError(t, err, "Error al abrir la base de datos")
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	sqlDB.SetMaxOpenConns(3)
+	conn, err := sqlDB.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Act
+	stats, err := checks.NewDatabasePoolStats(db).PoolStats()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.MaxOpenConnections)
+	assert.Equal(t, 1, stats.InUse, "La conexión reservada debería contar como en uso")
+	assert.Equal(t, stats.InUse+stats.Idle, stats.OpenConnections)
+}
@@ -0,0 +1,20 @@
+package checks_test
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"go-hexagonal-template/internal/modules/health/infrastructure/checks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskSpaceChecker_Check(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, checks.NewDiskSpaceChecker(dir, 0).Check(context.Background()), "Sin mínimo el chequeo debería pasar")
+	assert.Error(t, checks.NewDiskSpaceChecker(dir, math.MaxUint64).Check(context.Background()), "Un mínimo inalcanzable debería fallar")
+	assert.Error(t, checks.NewDiskSpaceChecker(filepath.Join(dir, "no-existe"), 0).Check(context.Background()), "Una ruta inexistente debería fallar")
+}
@@ -0,0 +1,47 @@
+package application_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/modules/shared/application"
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipPolicy_Authorize(t *testing.T) {
+	policy := application.NewOwnershipPolicy(map[string]string{
+		model.ActionRead: "items:read",
+	})
+	owned := model.Resource{Type: "item", ID: 10, OwnerID: 1}
+
+	tests := []struct {
+		name      string
+		principal *model.Principal
+		action    string
+		resource  model.Resource
+		allowed   bool
+	}{
+		{"el dueño puede leer", &model.Principal{UserID: 1}, model.ActionRead, owned, true},
+		{"el dueño puede actuar aunque la acción no tenga permiso", &model.Principal{UserID: 1}, model.ActionDelete, owned, true},
+		{"otro usuario no puede leer", &model.Principal{UserID: 2}, model.ActionRead, owned, false},
+		{"el permiso permite leer recursos ajenos", &model.Principal{UserID: 2, Permissions: []string{"items:read"}}, model.ActionRead, owned, true},
+		{"el permiso solo aplica a su acción", &model.Principal{UserID: 2, Permissions: []string{"items:read"}}, model.ActionDelete, owned, false},
+		{"un recurso sin dueño solo se accede con permiso", &model.Principal{UserID: 0}, model.ActionRead, model.Resource{Type: "item", ID: 10}, false},
+		{"sin principal se deniega", nil, model.ActionRead, owned, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := policy.Authorize(tt.principal, tt.action, tt.resource)
+
+			// Assert
+			if tt.allowed {
+				assert.NoError(t, err, "El acceso debería permitirse")
+			} else {
+				assert.ErrorIs(t, err, model.ErrForbidden, "El acceso debería denegarse")
+			}
+		})
+	}
+}
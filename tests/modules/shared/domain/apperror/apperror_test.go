@@ -0,0 +1,53 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go-hexagonal-template/internal/modules/shared/domain/apperror"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_WrapKeepsCause(t *testing.T) {
+	// Arrange
+	cause := errors.New("conexión rechazada")
+
+	// Act
+	err := apperror.Wrap(cause, apperror.KindInternal, apperror.CodeInternal, "Error al guardar")
+
+	// Assert
+	assert.ErrorIs(t, err, cause, "El error envuelto debería conservar la causa")
+	assert.Equal(t, "Error al guardar: conexión rechazada", err.Error(), "El mensaje debería incluir la causa")
+}
+
+func TestAs_FindsErrorInChain(t *testing.T) {
+	// Arrange
+	notFound := apperror.NotFound("ITEM_NOT_FOUND", "elemento no encontrado")
+	wrapped := fmt.Errorf("buscando elemento: %w", notFound)
+
+	// Act
+	appErr, ok := apperror.As(wrapped)
+
+	// Assert
+	assert.True(t, ok, "Debería encontrar el error del catálogo en la cadena")
+	assert.Equal(t, "ITEM_NOT_FOUND", appErr.Code, "El código no coincide")
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(wrapped), "El tipo no coincide")
+}
+
+func TestKindOf_UnknownErrorIsInternal(t *testing.T) {
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(errors.New("fallo inesperado")), "Los errores fuera del catálogo deberían ser internos")
+}
+
+func TestValidation_KeepsFieldErrors(t *testing.T) {
+	// Act
+	err := apperror.Validation(apperror.CodeValidationFailed, "datos inválidos",
+		apperror.FieldError{Field: "email", Code: "required", Message: "es obligatorio"},
+	)
+
+	// Assert
+	assert.Equal(t, apperror.KindValidation, err.Kind, "El tipo no coincide")
+	assert.Len(t, err.Fields, 1, "Debería conservar los errores por campo")
+	assert.Equal(t, "email", err.Fields[0].Field, "El campo no coincide")
+}
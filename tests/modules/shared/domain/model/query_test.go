@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/modules/shared/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	assert.Equal(t, model.Sort{Field: "name"}, model.ParseSort("name"), "Sin prefijo el orden debería ser ascendente")
+	assert.Equal(t, model.Sort{Field: "created_at", Descending: true}, model.ParseSort("-created_at"), "El prefijo - debería indicar orden descendente")
+	assert.Equal(t, "-created_at", model.ParseSort("-created_at").String(), "String debería ser el inverso de ParseSort")
+}
+
+func TestSort_Validate(t *testing.T) {
+	allowed := []string{"id", "name"}
+
+	assert.NoError(t, model.Sort{Field: "name"}.Validate(allowed), "Un campo permitido debería ser válido")
+	assert.ErrorIs(t, model.Sort{Field: "password"}.Validate(allowed), model.ErrInvalidQuery, "Un campo no permitido debería rechazarse")
+}
+
+func TestPageRequest_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  model.PageRequest
+		expected int
+		err      error
+	}{
+		{"aplica el límite por defecto", model.PageRequest{}, model.DefaultPageLimit, nil},
+		{"acota el límite máximo", model.PageRequest{Limit: 1000}, model.MaxPageLimit, nil},
+		{"conserva un límite válido", model.PageRequest{Limit: 5, Offset: 10}, 5, nil},
+		{"rechaza límites negativos", model.PageRequest{Limit: -1}, 0, model.ErrInvalidQuery},
+		{"rechaza combinar cursor y offset", model.PageRequest{Cursor: "abc", Offset: 10}, 0, model.ErrInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.request.Normalize()
+
+			// Assert
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.request.Limit, "El límite no coincide")
+		})
+	}
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	// Arrange
+	sort := model.Sort{Field: "created_at", Descending: true}
+	cursor := model.Cursor{Sort: sort.String(), Value: "2024-01-02T03:04:05Z", ID: 42}
+
+	// Act
+	decoded, err := model.DecodeCursor(cursor.Encode(), sort)
+
+	// Assert
+	assert.NoError(t, err, "El cursor debería decodificarse")
+	assert.Equal(t, cursor, decoded, "El cursor no coincide")
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	cursor := model.Cursor{Sort: "name", Value: "Ana", ID: 1}.Encode()
+
+	_, err := model.DecodeCursor("%%%", model.Sort{Field: "name"})
+	assert.ErrorIs(t, err, model.ErrInvalidCursor, "Un cursor malformado debería rechazarse")
+
+	_, err = model.DecodeCursor(cursor, model.Sort{Field: "email"})
+	assert.ErrorIs(t, err, model.ErrInvalidCursor, "Un cursor generado con otro orden debería rechazarse")
+}
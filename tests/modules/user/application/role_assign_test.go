@@ -0,0 +1,38 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignRoleUseCase_Execute(t *testing.T) {
+	// Arrange
+	roleRepo := mocks.NewRoleRepositoryMock()
+	useCase := application.NewAssignRoleUseCase(mocks.NewUserRepositoryMock(), roleRepo)
+
+	// Act
+	err := useCase.Execute(context.Background(), 2, model.RoleAdmin)
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al asignar el rol")
+	assert.Equal(t, []string{model.RoleAdmin}, roleRepo.RoleNames(2), "El usuario debería tener el rol admin")
+}
+
+func TestAssignRoleUseCase_Execute_UserNotFound(t *testing.T) {
+	// Arrange
+	roleRepo := mocks.NewRoleRepositoryMock()
+	useCase := application.NewAssignRoleUseCase(mocks.NewUserRepositoryMock(), roleRepo)
+
+	// Act
+	err := useCase.Execute(context.Background(), 9999, model.RoleAdmin)
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrUserNotFound)
+	assert.Empty(t, roleRepo.RoleNames(9999), "No debería asignar roles a un usuario inexistente")
+}
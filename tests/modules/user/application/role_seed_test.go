@@ -0,0 +1,96 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedRolesUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	roleRepo := mocks.NewRoleRepositoryMock()
+	useCase := application.NewSeedRolesUseCase(userRepo, roleRepo, mocks.NewTxManagerMock())
+	input := application.SeedRolesInput{
+		AdminEmail:    "Admin@example.com",
+		AdminPassword: "password123",
+	}
+
+	// Act
+	output, err := useCase.Execute(ctx, input)
+
+	// Assert
+	require.NoError(t, err, "No debería haber error al crear los roles")
+	assert.Nil(t, output.AdminSkipped, "El administrador no debería omitirse")
+
+	admin, err := roleRepo.GetByName(ctx, model.RoleAdmin)
+	assert.NoError(t, err, "El rol admin debería existir")
+	assert.ElementsMatch(t, []string{
+		model.PermissionUsersRead,
+		model.PermissionUsersWrite,
+		model.PermissionUsersDelete,
+	}, admin.PermissionNames(), "El rol admin debería tener todos los permisos")
+
+	_, err = roleRepo.GetByName(ctx, model.RoleUser)
+	assert.NoError(t, err, "El rol user debería existir")
+
+	user, err := userRepo.GetByEmail(ctx, "admin@example.com")
+	require.NoError(t, err, "El administrador debería crearse con el email normalizado")
+	assert.Equal(t, []string{model.RoleAdmin}, roleRepo.RoleNames(user.ID), "El administrador debería tener el rol admin")
+
+	// Ejecutarlo de nuevo no duplica asignaciones ni avisa
+	output, err = useCase.Execute(ctx, input)
+	require.NoError(t, err)
+	assert.Nil(t, output.AdminSkipped, "El administrador creado por la semilla no debería omitirse")
+	assert.Equal(t, []string{model.RoleAdmin}, roleRepo.RoleNames(user.ID), "La semilla debería ser idempotente")
+}
+
+func TestSeedRolesUseCase_Execute_DoesNotPromoteExistingAccount(t *testing.T) {
+	// Arrange: alguien se registró con el email del administrador antes del primer arranque
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	roleRepo := mocks.NewRoleRepositoryMock()
+	registered, err := userRepo.Create(ctx, &model.User{Email: "admin@example.com", Name: "Intruso", Password: "hash"})
+	require.NoError(t, err)
+	require.NoError(t, roleRepo.AssignToUser(ctx, registered.ID, model.RoleUser))
+	useCase := application.NewSeedRolesUseCase(userRepo, roleRepo, mocks.NewTxManagerMock())
+
+	// Act
+	output, err := useCase.Execute(ctx, application.SeedRolesInput{AdminEmail: "admin@example.com", AdminPassword: "password123"})
+
+	// Assert
+	require.NoError(t, err, "El arranque no debería fallar")
+	assert.ErrorIs(t, output.AdminSkipped, application.ErrSeedAdminNotAdmin, "Debería informarse de que se omitió el administrador")
+	assert.Equal(t, []string{model.RoleUser}, roleRepo.RoleNames(registered.ID), "Una cuenta existente no debería recibir el rol admin")
+}
+
+func TestSeedRolesUseCase_Execute_SkipsDeletedAdmin(t *testing.T) {
+	// Arrange: el administrador creado por la semilla se eliminó de forma lógica
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	roleRepo := mocks.NewRoleRepositoryMock()
+	useCase := application.NewSeedRolesUseCase(userRepo, roleRepo, mocks.NewTxManagerMock())
+	input := application.SeedRolesInput{AdminEmail: "admin@example.com", AdminPassword: "password123"}
+	_, err := useCase.Execute(ctx, input)
+	require.NoError(t, err)
+	admin, err := userRepo.GetByEmail(ctx, "admin@example.com")
+	require.NoError(t, err)
+	require.NoError(t, userRepo.Delete(ctx, admin.ID))
+
+	// Act
+	output, err := useCase.Execute(ctx, input)
+
+	// Assert
+	require.NoError(t, err, "Un administrador eliminado no debería impedir el arranque")
+	assert.ErrorIs(t, output.AdminSkipped, application.ErrSeedAdminDeleted)
+	_, err = userRepo.GetByEmail(ctx, "admin@example.com")
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "El administrador eliminado no debería restaurarse")
+}
@@ -1,9 +1,12 @@
 package application_test
 
 import (
+	"context"
 	"testing"
 
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
 	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/tests/mocks"
 
 	"github.com/stretchr/testify/assert"
@@ -12,15 +15,18 @@ import (
 func TestCreateUserUseCase_Execute(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewUserRepositoryMock()
-	useCase := application.NewCreateUserUseCase(mockRepo)
+	roleRepo := mocks.NewRoleRepositoryMock()
+	txManager := mocks.NewTxManagerMock()
+	outbox := mocks.NewEventOutboxMock()
+	useCase := application.NewCreateUserUseCase(mockRepo, roleRepo, txManager, outbox)
 	input := application.CreateUserInput{
-		Email:    "test@example.com",
+		Email:    "nonexistent@example.com",
 		Name:     "Test",
 		Password: "password123",
 	}
 
 	// Act
-	user, err := useCase.Execute(input)
+	user, err := useCase.Execute(context.Background(), input)
 
 	// Assert
 	assert.NoError(t, err, "No debería haber error al crear el usuario")
@@ -29,4 +35,39 @@ func TestCreateUserUseCase_Execute(t *testing.T) {
 	assert.Equal(t, input.Name, user.Name, "El nombre no coincide")
 	assert.NotEmpty(t, user.CreatedAt, "La fecha de creación no debería estar vacía")
 	assert.NotEmpty(t, user.UpdatedAt, "La fecha de actualización no debería estar vacía")
+	assert.Equal(t, []string{model.RoleUser}, roleRepo.RoleNames(user.ID), "El usuario debería recibir el rol básico")
+	assert.Equal(t, 1, txManager.Calls(), "El alta y el rol deberían guardarse en una transacción")
+	assert.Equal(t, []sharedmodel.Event{model.UserRegistered{UserID: user.ID, Email: user.Email}}, outbox.Events(), "Debería registrarse el evento de alta")
+}
+
+func TestCreateUserUseCase_Execute_NormalizesEmail(t *testing.T) {
+	// Arrange
+	useCase := application.NewCreateUserUseCase(mocks.NewUserRepositoryMock(), mocks.NewRoleRepositoryMock(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+
+	// Act
+	user, err := useCase.Execute(context.Background(), application.CreateUserInput{
+		Email:    "  NonExistent@Example.COM ",
+		Name:     "Test",
+		Password: "password123",
+	})
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al crear el usuario")
+	assert.Equal(t, "nonexistent@example.com", user.Email, "El email debería guardarse normalizado")
+}
+
+func TestCreateUserUseCase_Execute_EmailTaken(t *testing.T) {
+	// Arrange
+	useCase := application.NewCreateUserUseCase(mocks.NewUserRepositoryMock(), mocks.NewRoleRepositoryMock(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+
+	// Act
+	user, err := useCase.Execute(context.Background(), application.CreateUserInput{
+		Email:    "Test@Example.com",
+		Name:     "Test",
+		Password: "password123",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrEmailTaken, "Un email ya registrado debería rechazarse sin distinguir mayúsculas")
+	assert.Nil(t, user, "El usuario debería ser nil")
 }
@@ -0,0 +1,130 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingRevocationStore falla al revocar y registra si se llamó dentro de la transacción
+type failingRevocationStore struct {
+	port.TokenRevocationStore
+	inTx bool
+}
+
+func (s *failingRevocationStore) RevokeAllForUser(ctx context.Context, userID uint, before time.Time) error {
+	s.inTx = mocks.InTx(ctx)
+	return errors.New("base de datos no disponible")
+}
+
+func TestDeleteUserUseCase_Execute(t *testing.T) {
+	// Arrange
+	revocationStore := memory.NewTokenRevocationStore()
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	useCase := application.NewDeleteUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), revocationStore, refreshRepo, mocks.NewTxManagerMock())
+	// Como el iat de los tokens, con precisión de segundos
+	issuedAt := time.Now().Truncate(time.Second)
+
+	// Act
+	err := useCase.Execute(context.Background(), &sharedmodel.Principal{UserID: login.User.ID}, login.User.ID)
+
+	// Assert
+	assert.NoError(t, err, "Un usuario debería poder eliminarse a sí mismo")
+
+	revoked, err := revocationStore.IsRevoked(context.Background(), "token-1", login.User.ID, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked, "Los tokens de acceso del usuario deberían estar revocados")
+
+	refreshUseCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+	_, err = refreshUseCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "Las sesiones del usuario deberían estar revocadas")
+}
+
+func TestDeleteUserUseCase_Execute_RevokesInSameTransaction(t *testing.T) {
+	// Arrange
+	revocationStore := &failingRevocationStore{TokenRevocationStore: memory.NewTokenRevocationStore()}
+	txManager := mocks.NewTxManagerMock()
+	useCase := application.NewDeleteUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), revocationStore, mocks.NewRefreshTokenRepositoryMock(), txManager)
+
+	// Act
+	err := useCase.Execute(context.Background(), adminPrincipal(), 2)
+
+	// Assert
+	assert.Error(t, err, "Si falla la revocación la eliminación debería fallar")
+	assert.Equal(t, 1, txManager.Calls())
+	assert.True(t, revocationStore.inTx, "La revocación debería ejecutarse en la transacción de la eliminación")
+}
+
+func TestDeleteUserUseCase_Execute_Forbidden(t *testing.T) {
+	// Arrange
+	useCase := application.NewDeleteUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), memory.NewTokenRevocationStore(), mocks.NewRefreshTokenRepositoryMock(), mocks.NewTxManagerMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), &sharedmodel.Principal{UserID: 1}, 2)
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrForbidden, "Eliminar a otro usuario debería requerir users:delete")
+}
+
+func TestDeleteUserUseCase_Execute_NotFound(t *testing.T) {
+	// Arrange
+	useCase := application.NewDeleteUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), memory.NewTokenRevocationStore(), mocks.NewRefreshTokenRepositoryMock(), mocks.NewTxManagerMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), adminPrincipal(), 9999)
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "Debería retornar ErrUserNotFound")
+}
+
+func TestRestoreUserUseCase_Execute(t *testing.T) {
+	// Arrange
+	userRepo := mocks.NewUserRepositoryMock()
+	assert.NoError(t, userRepo.Delete(context.Background(), 2))
+	useCase := application.NewRestoreUserUseCase(userRepo)
+
+	// Act
+	user, err := useCase.Execute(context.Background(), 2)
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al restaurar el usuario")
+	assert.Equal(t, uint(2), user.ID, "El ID no coincide")
+
+	_, err = userRepo.GetByID(context.Background(), 2)
+	assert.NoError(t, err, "El usuario restaurado debería volver a encontrarse")
+}
+
+func TestHardDeleteUserUseCase_Execute_RevokesInSameTransaction(t *testing.T) {
+	// Arrange
+	revocationStore := &failingRevocationStore{TokenRevocationStore: memory.NewTokenRevocationStore()}
+	useCase := application.NewHardDeleteUserUseCase(mocks.NewUserRepositoryMock(), revocationStore, mocks.NewRefreshTokenRepositoryMock(), mocks.NewTxManagerMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), 2)
+
+	// Assert
+	assert.Error(t, err, "Si falla la revocación la eliminación debería fallar")
+	assert.True(t, revocationStore.inTx, "La revocación debería ejecutarse en la transacción de la eliminación")
+}
+
+func TestHardDeleteUserUseCase_Execute_NotFound(t *testing.T) {
+	// Arrange
+	useCase := application.NewHardDeleteUserUseCase(mocks.NewUserRepositoryMock(), memory.NewTokenRevocationStore(), mocks.NewRefreshTokenRepositoryMock(), mocks.NewTxManagerMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), 9999)
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "Debería retornar ErrUserNotFound")
+}
@@ -1,9 +1,12 @@
 package application_test
 
 import (
+	"context"
 	"testing"
 
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
 	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/tests/mocks"
 
 	"github.com/stretchr/testify/assert"
@@ -12,11 +15,11 @@ import (
 func TestGetUserUseCase_Execute(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewUserRepositoryMock()
-	useCase := application.NewGetUserUseCase(mockRepo)
-	expectedID := uint(123)
+	useCase := application.NewGetUserUseCase(mockRepo, application.NewUserPolicy())
+	expectedID := uint(1)
 
 	// Act
-	user, err := useCase.Execute(expectedID)
+	user, err := useCase.Execute(context.Background(), adminPrincipal(), expectedID)
 
 	// Assert
 	assert.NoError(t, err, "No debería haber error al obtener el usuario")
@@ -31,12 +34,98 @@ func TestGetUserUseCase_Execute(t *testing.T) {
 func TestGetUserUseCase_Execute_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewUserRepositoryMock()
-	useCase := application.NewGetUserUseCase(mockRepo)
+	useCase := application.NewGetUserUseCase(mockRepo, application.NewUserPolicy())
 
 	// Act
-	user, err := useCase.Execute(9999)
+	user, err := useCase.Execute(context.Background(), adminPrincipal(), 9999)
 
 	// Assert
 	assert.Error(t, err, "Debería retornar un error cuando el usuario no existe")
 	assert.Nil(t, user, "El usuario debería ser nil cuando no existe")
 }
+
+func TestGetUserUseCase_Execute_Self(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewUserRepositoryMock()
+	useCase := application.NewGetUserUseCase(mockRepo, application.NewUserPolicy())
+	principal := &sharedmodel.Principal{UserID: 2}
+
+	// Act
+	user, err := useCase.Execute(context.Background(), principal, 2)
+
+	// Assert
+	assert.NoError(t, err, "Un usuario debería poder consultarse a sí mismo")
+	assert.Equal(t, uint(2), user.ID, "El ID no coincide")
+}
+
+func TestGetUserUseCase_Execute_Forbidden(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewUserRepositoryMock()
+	useCase := application.NewGetUserUseCase(mockRepo, application.NewUserPolicy())
+	principal := &sharedmodel.Principal{UserID: 2, Roles: []string{model.RoleUser}}
+
+	// Act
+	user, err := useCase.Execute(context.Background(), principal, 9999)
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrForbidden, "Debería denegarse antes de comprobar si el usuario existe")
+	assert.Nil(t, user, "El usuario debería ser nil")
+}
+
+func TestGetUserUseCase_Execute_WithoutPrincipal(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewUserRepositoryMock()
+	useCase := application.NewGetUserUseCase(mockRepo, application.NewUserPolicy())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), nil, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrForbidden, "Sin principal el acceso debería denegarse")
+}
+
+// adminPrincipal retorna un principal con los permisos del rol administrador
+func adminPrincipal() *sharedmodel.Principal {
+	var admin model.Role
+	for _, role := range model.DefaultRoles() {
+		if role.Name == model.RoleAdmin {
+			admin = role
+		}
+	}
+	return &sharedmodel.Principal{
+		UserID:      1,
+		Roles:       []string{model.RoleAdmin},
+		Permissions: admin.PermissionNames(),
+	}
+}
+
+func TestFindUserByEmailUseCase_Execute(t *testing.T) {
+	// Arrange
+	useCase := application.NewFindUserByEmailUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy())
+
+	// Act
+	user, err := useCase.Execute(context.Background(), adminPrincipal(), "  Test@Example.com ")
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al buscar el usuario")
+	assert.Equal(t, "test@example.com", user.Email, "El email debería normalizarse antes de buscar")
+}
+
+func TestFindUserByEmailUseCase_Execute_NotFound(t *testing.T) {
+	useCase := application.NewFindUserByEmailUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy())
+
+	user, err := useCase.Execute(context.Background(), adminPrincipal(), "nonexistent@example.com")
+
+	assert.ErrorIs(t, err, model.ErrUserNotFound)
+	assert.Nil(t, user)
+}
+
+func TestFindUserByEmailUseCase_Execute_Forbidden(t *testing.T) {
+	useCase := application.NewFindUserByEmailUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy())
+
+	// test@example.com es el usuario 1
+	user, err := useCase.Execute(context.Background(), &sharedmodel.Principal{UserID: 2}, "test@example.com")
+
+	assert.ErrorIs(t, err, sharedmodel.ErrForbidden)
+	assert.Nil(t, user)
+}
@@ -1,9 +1,11 @@
 package application_test
 
 import (
+	"context"
 	"testing"
 
 	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
 	"go-hexagonal-template/tests/mocks"
 
 	"github.com/stretchr/testify/assert"
@@ -11,51 +13,61 @@ import (
 
 func TestLoginUserUseCase_Execute_Success(t *testing.T) {
 	mockRepo := mocks.NewUserRepositoryMock()
-	useCase := application.NewLoginUserUseCase(mockRepo)
+	outbox := mocks.NewEventOutboxMock()
+	useCase := application.NewLoginUserUseCase(mockRepo, newTokenIssuer(mocks.NewRefreshTokenRepositoryMock()), mocks.NewTxManagerMock(), outbox)
 	input := application.LoginUserInput{
 		Email:    "test@example.com",
 		Password: "password123",
 	}
 
 	// Act
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 
 	// Assert
 	assert.NoError(t, err, "No debería haber error en el login exitoso")
 	assert.NotNil(t, result, "El resultado no debería ser nil")
 	assert.NotEmpty(t, result.Token, "El token no debería estar vacío")
+	assert.NotEmpty(t, result.RefreshToken, "El refresh token no debería estar vacío")
+	assert.Positive(t, result.ExpiresIn, "La vigencia del token debería ser positiva")
 	assert.NotNil(t, result.User, "El usuario no debería ser nil")
 	assert.Equal(t, input.Email, result.User.Email, "El email no coincide")
+	if assert.Len(t, outbox.Events(), 1, "Debería registrarse el inicio de sesión") {
+		event := outbox.Events()[0].(model.UserLoggedIn)
+		assert.Equal(t, result.User.ID, event.UserID)
+		assert.NotEmpty(t, event.SessionID, "El evento debería identificar la sesión")
+	}
 }
 
 func TestLoginUserUseCase_Execute_InvalidEmail(t *testing.T) {
 	mockRepo := mocks.NewUserRepositoryMock()
-	useCase := application.NewLoginUserUseCase(mockRepo)
+	useCase := application.NewLoginUserUseCase(mockRepo, newTokenIssuer(mocks.NewRefreshTokenRepositoryMock()), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
 	input := application.LoginUserInput{
 		Email:    "nonexistent@example.com",
 		Password: "password123",
 	}
 
 	// Act
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 
 	// Assert
-	assert.Error(t, err, "Debería haber error con email inexistente")
+	assert.ErrorIs(t, err, application.ErrInvalidCredentials, "Debería haber error con email inexistente")
 	assert.Nil(t, result, "El resultado debería ser nil si el email no existe")
 }
 
 func TestLoginUserUseCase_Execute_InvalidPassword(t *testing.T) {
 	mockRepo := mocks.NewUserRepositoryMock()
-	useCase := application.NewLoginUserUseCase(mockRepo)
+	outbox := mocks.NewEventOutboxMock()
+	useCase := application.NewLoginUserUseCase(mockRepo, newTokenIssuer(mocks.NewRefreshTokenRepositoryMock()), mocks.NewTxManagerMock(), outbox)
 	input := application.LoginUserInput{
 		Email:    "test@example.com",
 		Password: "wrongpassword",
 	}
 
 	// Act
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 
 	// Assert
-	assert.Error(t, err, "Debería haber error con contraseña incorrecta")
+	assert.ErrorIs(t, err, application.ErrInvalidCredentials, "Debería haber error con contraseña incorrecta")
 	assert.Nil(t, result, "El resultado debería ser nil si la contraseña es incorrecta")
+	assert.Empty(t, outbox.Events(), "Un login fallido no debería registrar eventos")
 }
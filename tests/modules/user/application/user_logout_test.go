@@ -0,0 +1,97 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/infrastructure/auth"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sessionOf(t *testing.T, refreshRepo *mocks.RefreshTokenRepositoryMock, refreshToken string) string {
+	stored, err := refreshRepo.GetByHash(context.Background(), auth.HashRefreshToken(refreshToken))
+	assert.NoError(t, err, "El refresh token debería existir")
+	return stored.FamilyID
+}
+
+func TestLogoutUseCase_Execute(t *testing.T) {
+	// Arrange
+	revocationStore := memory.NewTokenRevocationStore()
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	useCase := application.NewLogoutUseCase(revocationStore, refreshRepo, mocks.NewTxManagerMock())
+	issuedAt := time.Now()
+
+	// Act
+	err := useCase.Execute(context.Background(), application.LogoutInput{
+		TokenID:   "token-1",
+		UserID:    login.User.ID,
+		SessionID: sessionOf(t, refreshRepo, login.RefreshToken),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al cerrar la sesión")
+
+	revoked, err := revocationStore.IsRevoked(context.Background(), "token-1", login.User.ID, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked, "El token de acceso debería estar revocado")
+
+	revoked, err = revocationStore.IsRevoked(context.Background(), "token-2", login.User.ID, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked, "Otros tokens no deberían verse afectados")
+
+	refreshUseCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+	_, err = refreshUseCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "El refresh token de la sesión debería estar revocado")
+}
+
+func TestLogoutAllUseCase_Execute(t *testing.T) {
+	// Arrange
+	revocationStore := memory.NewTokenRevocationStore()
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	useCase := application.NewLogoutAllUseCase(revocationStore, refreshRepo, mocks.NewTxManagerMock())
+	// Como el iat de los tokens, con precisión de segundos
+	issuedAt := time.Now().Truncate(time.Second)
+
+	// Act
+	err := useCase.Execute(context.Background(), login.User.ID)
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al cerrar todas las sesiones")
+
+	revoked, err := revocationStore.IsRevoked(context.Background(), "cualquier-token", login.User.ID, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked, "Los tokens emitidos antes deberían estar revocados")
+
+	revoked, err = revocationStore.IsRevoked(context.Background(), "cualquier-token", login.User.ID, time.Now().Truncate(time.Second))
+	assert.NoError(t, err)
+	assert.True(t, revoked, "Los tokens emitidos en el mismo segundo que el cierre deberían estar revocados")
+
+	revoked, err = revocationStore.IsRevoked(context.Background(), "cualquier-token", login.User.ID, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, revoked, "Los tokens emitidos después no deberían estar revocados")
+
+	refreshUseCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+	_, err = refreshUseCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "Los refresh tokens deberían estar revocados")
+}
+
+func TestLogoutAllUseCase_Execute_RevokesInTransaction(t *testing.T) {
+	// Arrange
+	revocationStore := &failingRevocationStore{TokenRevocationStore: memory.NewTokenRevocationStore()}
+	useCase := application.NewLogoutAllUseCase(revocationStore, mocks.NewRefreshTokenRepositoryMock(), mocks.NewTxManagerMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), 5)
+
+	// Assert
+	assert.Error(t, err, "Si falla la revocación el cierre de sesiones debería fallar")
+	assert.True(t, revocationStore.inTx, "La revocación debería ejecutarse en la transacción del cierre de sesiones")
+}
@@ -0,0 +1,68 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetPasswordUseCase_Execute(t *testing.T) {
+	// Arrange
+	revocationStore := memory.NewTokenRevocationStore()
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	useCase := application.NewResetPasswordUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), revocationStore, refreshRepo, mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+	// Como el iat de los tokens, con precisión de segundos
+	issuedAt := time.Now().Truncate(time.Second)
+
+	// Act
+	err := useCase.Execute(context.Background(), adminPrincipal(), login.User.ID, "nueva-contraseña")
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al restablecer la contraseña")
+
+	revoked, err := revocationStore.IsRevoked(context.Background(), "cualquier-token", login.User.ID, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked, "Los tokens emitidos antes del cambio deberían estar revocados")
+
+	refreshUseCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+	_, err = refreshUseCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "Las sesiones abiertas deberían cerrarse")
+}
+
+func TestResetPasswordUseCase_Execute_Forbidden(t *testing.T) {
+	// Arrange
+	revocationStore := memory.NewTokenRevocationStore()
+	useCase := application.NewResetPasswordUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), revocationStore, mocks.NewRefreshTokenRepositoryMock(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), &sharedmodel.Principal{UserID: 2}, 1, "nueva-contraseña")
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrForbidden, "Un usuario sin permisos no debería cambiar la contraseña de otro")
+
+	revoked, err := revocationStore.IsRevoked(context.Background(), "cualquier-token", 1, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, revoked, "No debería cerrar sesiones si el cambio se rechaza")
+}
+
+func TestResetPasswordUseCase_Execute_RevokesInSameTransaction(t *testing.T) {
+	// Arrange
+	revocationStore := &failingRevocationStore{TokenRevocationStore: memory.NewTokenRevocationStore()}
+	txManager := mocks.NewTxManagerMock()
+	useCase := application.NewResetPasswordUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), revocationStore, mocks.NewRefreshTokenRepositoryMock(), txManager, mocks.NewEventOutboxMock())
+
+	// Act
+	err := useCase.Execute(context.Background(), adminPrincipal(), 2, "nueva-contraseña")
+
+	// Assert
+	assert.Error(t, err, "Si no pueden cerrarse las sesiones el cambio de contraseña debería fallar")
+	assert.True(t, revocationStore.inTx, "La revocación debería ejecutarse en la transacción del cambio de contraseña")
+}
@@ -0,0 +1,153 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unavailableRefreshRepository simula fallos de la base de datos y registra si MarkUsed se
+// llamó dentro de la transacción
+type unavailableRefreshRepository struct {
+	*mocks.RefreshTokenRepositoryMock
+	failLookup bool
+	markedInTx bool
+}
+
+func (r *unavailableRefreshRepository) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	if r.failLookup {
+		return nil, errDatabaseUnavailable
+	}
+	return r.RefreshTokenRepositoryMock.GetByHash(ctx, hash)
+}
+
+func (r *unavailableRefreshRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	r.markedInTx = mocks.InTx(ctx)
+	return r.RefreshTokenRepositoryMock.MarkUsed(ctx, id, usedAt)
+}
+
+func (r *unavailableRefreshRepository) Create(ctx context.Context, token *model.RefreshToken) (*model.RefreshToken, error) {
+	return nil, errDatabaseUnavailable
+}
+
+var errDatabaseUnavailable = errors.New("base de datos no disponible")
+
+func newTokenIssuer(refreshRepo port.RefreshTokenRepository) *application.TokenIssuer {
+	return application.NewTokenIssuer(mocks.NewTokenManager(), refreshRepo, mocks.NewRoleRepositoryMock())
+}
+
+func loginForRefresh(t *testing.T, refreshRepo *mocks.RefreshTokenRepositoryMock) *application.LoginUserOutput {
+	loginUseCase := application.NewLoginUserUseCase(mocks.NewUserRepositoryMock(), newTokenIssuer(refreshRepo), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+	result, err := loginUseCase.Execute(context.Background(), application.LoginUserInput{
+		Email:    "test@example.com",
+		Password: "password123",
+	})
+	assert.NoError(t, err, "No debería haber error en el login")
+	return result
+}
+
+func TestRefreshTokenUseCase_Execute_Rotates(t *testing.T) {
+	// Arrange
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	useCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+
+	// Act
+	result, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al renovar el token")
+	assert.NotEmpty(t, result.Token, "El token no debería estar vacío")
+	assert.NotEmpty(t, result.RefreshToken, "El refresh token no debería estar vacío")
+	assert.NotEqual(t, login.RefreshToken, result.RefreshToken, "El refresh token debería rotar")
+
+	// El nuevo refresh token también debe poder usarse
+	_, err = useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: result.RefreshToken})
+	assert.NoError(t, err, "El refresh token rotado debería ser válido")
+}
+
+func TestRefreshTokenUseCase_Execute_ReuseRevokesFamily(t *testing.T) {
+	// Arrange
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	useCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+	rotated, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+	assert.NoError(t, err, "No debería haber error al renovar el token")
+
+	// Act
+	result, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+
+	// Assert
+	assert.ErrorIs(t, err, application.ErrRefreshTokenReused, "Debería detectar la reutilización")
+	assert.Nil(t, result, "El resultado debería ser nil")
+
+	_, err = useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: rotated.RefreshToken})
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "Toda la familia debería estar revocada")
+}
+
+func TestRefreshTokenUseCase_Execute_Expired(t *testing.T) {
+	// Arrange
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	refreshRepo.Expire()
+	useCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+
+	// Act
+	result, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+
+	// Assert
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "Debería rechazar el token vencido")
+	assert.Nil(t, result, "El resultado debería ser nil")
+}
+
+func TestRefreshTokenUseCase_Execute_Unknown(t *testing.T) {
+	// Arrange
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	useCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+
+	// Act
+	result, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: "desconocido"})
+
+	// Assert
+	assert.ErrorIs(t, err, application.ErrInvalidRefreshToken, "Debería rechazar un token desconocido")
+	assert.Nil(t, result, "El resultado debería ser nil")
+}
+
+func TestRefreshTokenUseCase_Execute_IssuesInSameTransaction(t *testing.T) {
+	// Arrange
+	refreshRepo := mocks.NewRefreshTokenRepositoryMock()
+	login := loginForRefresh(t, refreshRepo)
+	failing := &unavailableRefreshRepository{RefreshTokenRepositoryMock: refreshRepo}
+	txManager := mocks.NewTxManagerMock()
+	useCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), failing, newTokenIssuer(failing), txManager)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: login.RefreshToken})
+
+	// Assert
+	assert.ErrorIs(t, err, errDatabaseUnavailable, "Un fallo al emitir el par debería retornarse tal cual")
+	assert.Nil(t, result, "El resultado debería ser nil")
+	assert.Equal(t, 1, txManager.Calls())
+	assert.True(t, failing.markedInTx, "El token debería marcarse como usado en la transacción de la emisión")
+}
+
+func TestRefreshTokenUseCase_Execute_RepositoryError(t *testing.T) {
+	// Arrange
+	refreshRepo := &unavailableRefreshRepository{RefreshTokenRepositoryMock: mocks.NewRefreshTokenRepositoryMock(), failLookup: true}
+	useCase := application.NewRefreshTokenUseCase(mocks.NewUserRepositoryMock(), refreshRepo, newTokenIssuer(refreshRepo), mocks.NewTxManagerMock())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), application.RefreshTokenInput{RefreshToken: "cualquiera"})
+
+	// Assert
+	assert.ErrorIs(t, err, errDatabaseUnavailable, "Un fallo de la base de datos no debería tratarse como token inválido")
+	assert.NotErrorIs(t, err, application.ErrInvalidRefreshToken)
+}
@@ -0,0 +1,118 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/application"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUpdateUserUseCase_Execute(t *testing.T) {
+	// Arrange
+	outbox := mocks.NewEventOutboxMock()
+	useCase := application.NewUpdateUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), mocks.NewTxManagerMock(), outbox)
+	principal := &sharedmodel.Principal{UserID: 1}
+
+	// Act
+	user, err := useCase.Execute(context.Background(), principal, 1, application.UpdateUserInput{
+		Email: "nonexistent@example.com",
+		Name:  "Updated",
+	})
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al actualizar el usuario")
+	assert.Equal(t, "nonexistent@example.com", user.Email, "El email no coincide")
+	assert.Equal(t, "Updated", user.Name, "El nombre no coincide")
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("password123")), "La contraseña no debería cambiar si no se envía")
+	assert.Empty(t, outbox.Events(), "Sin cambio de contraseña no debería registrarse ningún evento")
+}
+
+func TestPatchUserUseCase_Execute(t *testing.T) {
+	// Arrange
+	outbox := mocks.NewEventOutboxMock()
+	useCase := application.NewPatchUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), mocks.NewTxManagerMock(), outbox)
+	principal := &sharedmodel.Principal{UserID: 1}
+	password := "new-password"
+
+	// Act
+	user, err := useCase.Execute(context.Background(), principal, 1, application.PatchUserInput{Password: &password})
+
+	// Assert
+	assert.NoError(t, err, "No debería haber error al actualizar el usuario")
+	assert.Equal(t, "test@example.com", user.Email, "El email no debería cambiar")
+	assert.Equal(t, "Test", user.Name, "El nombre no debería cambiar")
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)), "La contraseña debería estar hasheada")
+	assert.Equal(t, []sharedmodel.Event{model.PasswordChanged{UserID: 1}}, outbox.Events(), "Debería registrarse el cambio de contraseña")
+}
+
+// txCheckingRepository registra si GetByID se llamó dentro de la transacción
+type txCheckingRepository struct {
+	*mocks.UserRepositoryMock
+	readOutsideTx bool
+}
+
+func (r *txCheckingRepository) GetByID(ctx context.Context, id uint) (*model.User, error) {
+	if !mocks.InTx(ctx) {
+		r.readOutsideTx = true
+	}
+	return r.UserRepositoryMock.GetByID(ctx, id)
+}
+
+func TestPatchUserUseCase_Execute_ReadsInsideTransaction(t *testing.T) {
+	// Arrange
+	repository := &txCheckingRepository{UserRepositoryMock: mocks.NewUserRepositoryMock()}
+	useCase := application.NewPatchUserUseCase(repository, application.NewUserPolicy(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+	name := "Otro"
+
+	// Act
+	_, err := useCase.Execute(context.Background(), adminPrincipal(), 2, application.PatchUserInput{Name: &name})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, repository.readOutsideTx, "El usuario debería leerse dentro de la transacción para no usar una réplica")
+}
+
+func TestPatchUserUseCase_Execute_Forbidden(t *testing.T) {
+	// Arrange
+	useCase := application.NewPatchUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+	principal := &sharedmodel.Principal{UserID: 1, Permissions: []string{model.PermissionUsersRead}}
+	name := "Otro"
+
+	// Act
+	user, err := useCase.Execute(context.Background(), principal, 2, application.PatchUserInput{Name: &name})
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrForbidden, "Actualizar a otro usuario debería requerir users:write")
+	assert.Nil(t, user, "El usuario debería ser nil")
+}
+
+func TestPatchUserUseCase_Execute_NotFound(t *testing.T) {
+	// Arrange
+	useCase := application.NewPatchUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), adminPrincipal(), 9999, application.PatchUserInput{})
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrUserNotFound, "Debería retornar ErrUserNotFound")
+}
+
+func TestPatchUserUseCase_Execute_EmailTaken(t *testing.T) {
+	// Arrange
+	useCase := application.NewPatchUserUseCase(mocks.NewUserRepositoryMock(), application.NewUserPolicy(), mocks.NewTxManagerMock(), mocks.NewEventOutboxMock())
+	principal := &sharedmodel.Principal{UserID: 1}
+	email := "Other@Example.com"
+
+	// Act
+	user, err := useCase.Execute(context.Background(), principal, 1, application.PatchUserInput{Email: &email})
+
+	// Assert
+	assert.ErrorIs(t, err, model.ErrEmailTaken, "El email de otro usuario no debería poder usarse")
+	assert.Nil(t, user, "El usuario debería ser nil")
+}
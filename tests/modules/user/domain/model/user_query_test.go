@@ -0,0 +1,37 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserQuery_Normalize(t *testing.T) {
+	t.Run("aplica el orden y el límite por defecto", func(t *testing.T) {
+		query := model.UserQuery{}
+
+		err := query.Normalize()
+
+		assert.NoError(t, err)
+		assert.Equal(t, sharedmodel.Sort{Field: "id"}, query.Sort, "El orden por defecto debería ser por id")
+		assert.Equal(t, sharedmodel.DefaultPageLimit, query.Page.Limit, "El límite por defecto no coincide")
+	})
+
+	t.Run("rechaza campos de orden no permitidos", func(t *testing.T) {
+		query := model.UserQuery{Sort: sharedmodel.Sort{Field: "password"}}
+
+		assert.ErrorIs(t, query.Normalize(), sharedmodel.ErrInvalidQuery)
+	})
+
+	t.Run("rechaza rangos de fechas invertidos", func(t *testing.T) {
+		now := time.Now()
+		earlier := now.Add(-time.Hour)
+		query := model.UserQuery{Filter: model.UserFilter{CreatedAfter: &now, CreatedBefore: &earlier}}
+
+		assert.ErrorIs(t, query.Normalize(), sharedmodel.ErrInvalidQuery)
+	})
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/memory"
+	"go-hexagonal-template/tests/contracts"
+)
+
+func TestUserRepository_Contract(t *testing.T) {
+	contracts.RunUserRepository(t, func(t *testing.T) port.UserRepository {
+		return memory.NewUserRepository()
+	})
+}
@@ -0,0 +1,62 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/migrations"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_ContainsInitialSchema(t *testing.T) {
+	list, err := migrations.Load(persistence.Migrations())
+
+	require.NoError(t, err, "Las migraciones incluidas en el binario deberían ser válidas")
+	require.NotEmpty(t, list)
+	assert.Equal(t, "initial_schema", list[0].Name)
+	assert.Contains(t, list[0].Up, "CREATE TABLE IF NOT EXISTS users")
+}
+
+func TestMigrations_HaveScriptsForEachDriver(t *testing.T) {
+	keywords := map[string]string{
+		migrations.DriverPostgres: "BIGSERIAL",
+		migrations.DriverMySQL:    "AUTO_INCREMENT",
+		migrations.DriverSQLite:   "AUTOINCREMENT",
+	}
+	for driver, keyword := range keywords {
+		t.Run(driver, func(t *testing.T) {
+			list, err := migrations.LoadFor(driver, persistence.Migrations())
+
+			require.NoError(t, err, "Las migraciones del motor deberían ser válidas")
+			require.NotEmpty(t, list)
+			assert.Contains(t, list[0].Up, "CREATE TABLE IF NOT EXISTS users")
+			assert.Contains(t, list[0].Up, keyword, "Debería usarse el script del motor")
+		})
+	}
+}
+
+func TestMigrations_LowercaseEmails(t *testing.T) {
+	// Arrange: un usuario guardado antes de normalizar los emails
+	db := setupSQLiteDB(t)
+	require.NoError(t, db.Exec("INSERT INTO users (name, email) VALUES (?, ?)", "Ana", " Ana@Example.COM").Error)
+	list, err := migrations.LoadFor(migrations.DriverSQLite, persistence.Migrations())
+	require.NoError(t, err)
+	var script string
+	for _, migration := range list {
+		if migration.Name == "lowercase_emails" {
+			script = migration.Up
+		}
+	}
+	require.NotEmpty(t, script, "Debería existir la migración lowercase_emails")
+
+	// Act
+	require.NoError(t, db.Exec(script).Error, "La migración debería aplicarse en SQLite")
+
+	// Assert
+	user, err := persistence.NewUserRepositoryImpl(db).GetByEmail(context.Background(), "ana@example.com")
+	require.NoError(t, err, "El email antiguo debería encontrarse comparando la columna tal cual")
+	assert.Equal(t, "ana@example.com", user.Email)
+}
@@ -0,0 +1,34 @@
+package persistence_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go-hexagonal-template/internal/infrastructure/config"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupSQLiteDB abre una base de datos SQLite en un fichero temporal con la misma
+// configuración que usa el servidor y le aplica las migraciones del módulo
+func setupSQLiteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	databaseConfig := &config.DatabaseConfig{
+		Driver: config.DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "users.db"),
+	}
+	db, err := databaseConfig.Connect(context.Background())
+	require.NoError(t, err, "Error al abrir la base de datos SQLite")
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	require.NoError(t, databaseConfig.Migrate(context.Background(), db, persistence.Migrations()),
+		"Las migraciones deberían aplicarse en SQLite")
+	return db
+}
@@ -0,0 +1,53 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRevocationStoreImpl_RevokeAllForUser_SecondPrecision(t *testing.T) {
+	// Arrange
+	store := persistence.NewTokenRevocationStoreImpl(setupSQLiteDB(t))
+	revokedAt := time.Now().Truncate(time.Second).Add(500 * time.Millisecond)
+	require.NoError(t, store.RevokeAllForUser(context.Background(), 1, revokedAt))
+
+	// Act
+	before, err := store.IsRevoked(context.Background(), "token-1", 1, revokedAt.Add(-time.Second))
+	require.NoError(t, err)
+	sameSecond, err := store.IsRevoked(context.Background(), "token-2", 1, revokedAt.Truncate(time.Second))
+	require.NoError(t, err)
+	after, err := store.IsRevoked(context.Background(), "token-3", 1, revokedAt.Truncate(time.Second).Add(time.Second))
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, before, "Los tokens de segundos anteriores deberían estar revocados")
+	assert.True(t, sameSecond, "Un token emitido antes en el mismo segundo que la revocación debería estar revocado")
+	assert.False(t, after, "Los tokens de segundos posteriores no deberían estar revocados")
+}
+
+func TestTokenRevocationStoreImpl_PurgeExpired(t *testing.T) {
+	// Arrange
+	store := persistence.NewTokenRevocationStoreImpl(setupSQLiteDB(t))
+	now := time.Now()
+	require.NoError(t, store.Revoke(context.Background(), "vencido", 1, now.Add(-time.Minute)))
+	require.NoError(t, store.Revoke(context.Background(), "vigente", 1, now.Add(time.Minute)))
+
+	// Act
+	purged, err := store.PurgeExpired(context.Background(), now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged, "Solo debería eliminarse la revocación vencida")
+	revoked, err := store.IsRevoked(context.Background(), "vencido", 1, now)
+	require.NoError(t, err)
+	assert.False(t, revoked, "La revocación vencida debería haberse eliminado")
+	revoked, err = store.IsRevoked(context.Background(), "vigente", 1, now)
+	require.NoError(t, err)
+	assert.True(t, revoked, "La revocación vigente debería conservarse")
+}
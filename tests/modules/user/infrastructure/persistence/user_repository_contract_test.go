@@ -0,0 +1,15 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+	"go-hexagonal-template/tests/contracts"
+)
+
+func TestUserRepositoryImpl_Contract(t *testing.T) {
+	contracts.RunUserRepository(t, func(t *testing.T) port.UserRepository {
+		return persistence.NewUserRepositoryImpl(setupSQLiteDB(t))
+	})
+}
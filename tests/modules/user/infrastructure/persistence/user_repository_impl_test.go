@@ -1,20 +1,41 @@
 package persistence_test
 
 import (
+	"context"
 	"testing"
-	"time"
 
 	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
 	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
-// MockDB es un mock de la base de datos para testing
+// MockDB es un mock de la base de datos para provocar errores de cada driver. El
+// comportamiento del repositorio se comprueba con la suite de contratos sobre SQLite.
 type MockDB struct {
 	mock.Mock
+	// ctx es el último contexto recibido por WithContext o ForRead
+	ctx context.Context
+	// forRead indica si la última sesión se pidió con ForRead
+	forRead bool
+}
+
+func (m *MockDB) WithContext(ctx context.Context) persistence.DBInterface {
+	m.ctx = ctx
+	m.forRead = false
+	return m
+}
+
+func (m *MockDB) ForRead(ctx context.Context) persistence.DBInterface {
+	m.ctx = ctx
+	m.forRead = true
+	return m
 }
 
 func (m *MockDB) Create(value interface{}) *gorm.DB {
@@ -27,93 +48,140 @@ func (m *MockDB) First(dest interface{}, conds ...interface{}) *gorm.DB {
 	return args.Get(0).(*gorm.DB)
 }
 
+func (m *MockDB) Find(dest interface{}, conds ...interface{}) *gorm.DB {
+	args := m.Called(dest, conds)
+	return args.Get(0).(*gorm.DB)
+}
+
+func (m *MockDB) Save(value interface{}) *gorm.DB {
+	args := m.Called(value)
+	return args.Get(0).(*gorm.DB)
+}
+
+func (m *MockDB) Delete(value interface{}, conds ...interface{}) *gorm.DB {
+	args := m.Called(value, conds)
+	return args.Get(0).(*gorm.DB)
+}
+
+func (m *MockDB) Unscoped() *gorm.DB {
+	args := m.Called()
+	return args.Get(0).(*gorm.DB)
+}
+
+func (m *MockDB) Model(value interface{}) *gorm.DB {
+	args := m.Called(value)
+	return args.Get(0).(*gorm.DB)
+}
+
 func setupTestDB() *MockDB {
 	return new(MockDB)
 }
 
-func TestUserRepositoryImpl_Create(t *testing.T) {
-	// Arrange
-	mockDB := setupTestDB()
-	repo := persistence.NewUserRepositoryImpl(mockDB)
-	now := time.Now()
-	user := &model.User{
-		ID:        1,
-		Email:     "test@example.com",
-		Name:      "Test",
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
+// createUser guarda un usuario de prueba en la base de datos
+func createUser(t *testing.T, repo port.UserRepository, email string) *model.User {
+	t.Helper()
+	user, err := repo.Create(context.Background(), &model.User{Email: email, Name: "Test", Password: "hash"})
+	require.NoError(t, err, "Error al crear el usuario de prueba")
+	return user
+}
 
-	// Configurar el mock para Create
-	mockDB.On("Create", user).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*model.User)
-		// Asegurarse de que el ID se mantiene
-		assert.Equal(t, uint(1), arg.ID, "El ID debería mantenerse")
-	}).Return(&gorm.DB{})
+func TestUserRepositoryImpl_HardDelete_RemovesRoleAssignments(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+	repo := persistence.NewUserRepositoryImpl(db)
+	roleRepo := persistence.NewRoleRepositoryImpl(db)
+	ctx := context.Background()
+	_, err := roleRepo.Save(ctx, &model.Role{Name: "editor"})
+	require.NoError(t, err)
+	user := createUser(t, repo, "test@example.com")
+	require.NoError(t, roleRepo.AssignToUser(ctx, user.ID, "editor"))
 
 	// Act
-	createdUser, err := repo.Create(user)
+	err = repo.HardDelete(ctx, user.ID)
 
 	// Assert
-	assert.NoError(t, err, "Error al crear el usuario")
-	assert.NotEmpty(t, createdUser.ID, "El ID del usuario no debería estar vacío")
-	assert.Equal(t, uint(1), createdUser.ID, "El ID debería ser el mismo")
-	assert.Equal(t, user.Email, createdUser.Email, "El email no coincide")
-	assert.Equal(t, user.Name, createdUser.Name, "El nombre no coincide")
-	assert.Equal(t, user.CreatedAt, createdUser.CreatedAt, "La fecha de creación no coincide")
-	assert.Equal(t, user.UpdatedAt, createdUser.UpdatedAt, "La fecha de actualización no coincide")
+	assert.NoError(t, err, "Error al eliminar definitivamente el usuario")
+	var assignments int64
+	require.NoError(t, db.Table("user_roles").Where("user_id = ?", user.ID).Count(&assignments).Error)
+	assert.Zero(t, assignments, "Deberían eliminarse también sus roles")
+	assert.ErrorIs(t, repo.HardDelete(ctx, user.ID), model.ErrUserNotFound)
+}
 
-	mockDB.AssertExpectations(t)
+func TestUserRepositoryImpl_TranslatesDriverUniqueViolations(t *testing.T) {
+	driverErrors := map[string]error{
+		"postgres": &pgconn.PgError{Code: "23505"},
+		"mysql":    &mysql.MySQLError{Number: 1062},
+		"gorm":     gorm.ErrDuplicatedKey,
+	}
+	for driver, driverErr := range driverErrors {
+		t.Run(driver, func(t *testing.T) {
+			// Arrange
+			mockDB := setupTestDB()
+			repo := persistence.NewUserRepositoryWithDB(mockDB)
+			user := &model.User{Email: "test@example.com", Name: "Test"}
+			mockDB.On("Create", user).Return(&gorm.DB{Error: driverErr})
+
+			// Act
+			_, err := repo.Create(context.Background(), user)
+
+			// Assert
+			assert.ErrorIs(t, err, model.ErrEmailTaken, "La violación de unicidad debería traducirse a ErrEmailTaken")
+			mockDB.AssertExpectations(t)
+		})
+	}
 }
 
-func TestUserRepositoryImpl_GetByID(t *testing.T) {
+func TestUserRepositoryImpl_KeepsOtherDriverErrors(t *testing.T) {
 	// Arrange
 	mockDB := setupTestDB()
-	repo := persistence.NewUserRepositoryImpl(mockDB)
-	now := time.Now()
-	user := &model.User{
-		ID:        1,
-		Email:     "test@example.com",
-		Name:      "Test",
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
-
-	// Configurar el mock para First
-	mockDB.On("First", mock.Anything, []interface{}{"id = ?", user.ID}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*model.User)
-		*arg = *user
-	}).Return(&gorm.DB{})
+	repo := persistence.NewUserRepositoryWithDB(mockDB)
+	user := &model.User{Email: "test@example.com", Name: "Test"}
+	driverErr := &mysql.MySQLError{Number: 1452}
+	mockDB.On("Create", user).Return(&gorm.DB{Error: driverErr})
 
 	// Act
-	foundUser, err := repo.GetByID(user.ID)
+	_, err := repo.Create(context.Background(), user)
 
 	// Assert
-	assert.NoError(t, err, "Error al buscar el usuario por ID")
-	assert.NotNil(t, foundUser, "El usuario encontrado no debería ser nil")
-	assert.Equal(t, user.ID, foundUser.ID, "El ID no coincide")
-	assert.Equal(t, user.Email, foundUser.Email, "El email no coincide")
-	assert.Equal(t, user.Name, foundUser.Name, "El nombre no coincide")
-	assert.Equal(t, user.CreatedAt, foundUser.CreatedAt, "La fecha de creación no coincide")
-	assert.Equal(t, user.UpdatedAt, foundUser.UpdatedAt, "La fecha de actualización no coincide")
-
-	mockDB.AssertExpectations(t)
+	assert.ErrorIs(t, err, driverErr, "Los demás errores del driver deberían propagarse sin traducir")
+	assert.NotErrorIs(t, err, model.ErrEmailTaken)
 }
 
-func TestUserRepositoryImpl_GetByID_NotFound(t *testing.T) {
+func TestUserRepositoryImpl_PropagatesContext(t *testing.T) {
 	// Arrange
 	mockDB := setupTestDB()
-	repo := persistence.NewUserRepositoryImpl(mockDB)
+	repo := persistence.NewUserRepositoryWithDB(mockDB)
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "petición")
 
-	// Configurar el mock para First con error
-	mockDB.On("First", mock.Anything, []interface{}{"id = ?", uint(9999)}).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	mockDB.On("First", mock.Anything, []interface{}{"id = ?", uint(1)}).Return(&gorm.DB{})
 
 	// Act
-	user, err := repo.GetByID(9999)
+	_, err := repo.GetByID(ctx, 1)
 
 	// Assert
-	assert.Error(t, err, "Debería retornar un error cuando el usuario no existe")
-	assert.Nil(t, user, "El usuario debería ser nil cuando no existe")
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, mockDB.ctx, "La consulta debería ejecutarse con el contexto de la petición")
+}
 
-	mockDB.AssertExpectations(t)
+func TestUserRepositoryImpl_OnlyGetByIDUsesReadReplicas(t *testing.T) {
+	// Arrange
+	mockDB := setupTestDB()
+	repo := persistence.NewUserRepositoryWithDB(mockDB)
+	mockDB.On("First", mock.Anything, mock.Anything).Return(&gorm.DB{})
+	user := &model.User{Email: "test@example.com", Name: "Test"}
+	mockDB.On("Create", user).Return(&gorm.DB{})
+
+	// Act & Assert
+	_, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.True(t, mockDB.forRead, "GetByID debería poder leer de una réplica")
+
+	_, err = repo.GetByEmail(context.Background(), "test@example.com")
+	require.NoError(t, err)
+	assert.False(t, mockDB.forRead, "GetByEmail debería leer del primario: lo usan el login y las altas")
+
+	_, err = repo.Create(context.Background(), user)
+	require.NoError(t, err)
+	assert.False(t, mockDB.forRead, "Las escrituras deberían ir al primario")
 }
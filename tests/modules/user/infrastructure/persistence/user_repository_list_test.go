@@ -0,0 +1,138 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sharedmodel "go-hexagonal-template/internal/modules/shared/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/model"
+	"go-hexagonal-template/internal/modules/user/domain/port"
+	"go-hexagonal-template/internal/modules/user/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func normalizedQuery(t *testing.T, query model.UserQuery) model.UserQuery {
+	require.NoError(t, query.Normalize(), "La consulta debería ser válida")
+	return query
+}
+
+// seedUsers guarda los usuarios con fechas de creación consecutivas a partir de start
+func seedUsers(t *testing.T, db *gorm.DB, start time.Time, users ...model.User) []model.User {
+	t.Helper()
+	for i := range users {
+		users[i].Password = "hash"
+		users[i].CreatedAt = start.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, db.Create(&users[i]).Error, "Error al guardar el usuario de prueba")
+	}
+	return users
+}
+
+func emails(users []model.User) []string {
+	result := make([]string, 0, len(users))
+	for _, user := range users {
+		result = append(result, user.Email)
+	}
+	return result
+}
+
+func TestUserRepositoryImpl_List_Default(t *testing.T) {
+	// Arrange
+	repo := persistence.NewUserRepositoryImpl(setupSQLiteDB(t))
+
+	// Act
+	page, err := repo.List(context.Background(), normalizedQuery(t, model.UserQuery{}))
+
+	// Assert
+	assert.NoError(t, err, "Error al listar los usuarios")
+	require.NotNil(t, page.Total, "La paginación por desplazamiento debería calcular el total")
+	assert.Zero(t, *page.Total)
+	assert.Equal(t, []model.User{}, page.Items, "Sin resultados la página debería tener una lista vacía")
+	assert.Empty(t, page.NextCursor, "Sin más resultados no debería haber cursor")
+}
+
+func TestUserRepositoryImpl_List_Filters(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+	repo := persistence.NewUserRepositoryImpl(db)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := seedUsers(t, db, start,
+		model.User{Name: "Antes", Email: "ex_ample0@test.com"},
+		model.User{Name: "Al 50%", Email: "ex_ample1@test.com"},
+		model.User{Name: "Al 500", Email: "ex_ample2@test.com"},
+		model.User{Name: "Al 50%", Email: "example3@test.com"},
+		model.User{Name: "Borrado 50%", Email: "Ex_Ample4@test.com"},
+	)
+	require.NoError(t, db.Delete(&users[4]).Error)
+	after := start
+
+	// Act
+	page, err := repo.List(context.Background(), normalizedQuery(t, model.UserQuery{
+		Filter: model.UserFilter{
+			Email:          "Ex_ample",
+			Name:           "50%",
+			CreatedAfter:   &after,
+			IncludeDeleted: true,
+		},
+		Sort: sharedmodel.Sort{Field: "name"},
+	}))
+
+	// Assert
+	assert.NoError(t, err, "Error al listar los usuarios")
+	require.NotNil(t, page.Total)
+	assert.Equal(t, int64(2), *page.Total)
+	assert.Equal(t, []string{"ex_ample1@test.com", "Ex_Ample4@test.com"}, emails(page.Items),
+		"Los filtros deberían tratar los comodines como texto, ignorar mayúsculas e incluir los borrados")
+}
+
+func TestUserRepositoryImpl_List_Cursor(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+	repo := persistence.NewUserRepositoryImpl(db)
+	seedUsers(t, db, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		model.User{Name: "Uno", Email: "uno@test.com"},
+		model.User{Name: "Dos", Email: "dos@test.com"},
+		model.User{Name: "Tres", Email: "tres@test.com"},
+	)
+	query := model.UserQuery{
+		Sort: sharedmodel.Sort{Field: "created_at", Descending: true},
+		Page: sharedmodel.PageRequest{Limit: 2},
+	}
+	first := listPage(t, repo, query)
+	require.NotEmpty(t, first.NextCursor, "La primera página debería tener cursor")
+
+	// Act
+	query.Page.Cursor = first.NextCursor
+	second := listPage(t, repo, query)
+
+	// Assert
+	assert.Equal(t, []string{"tres@test.com", "dos@test.com"}, emails(first.Items))
+	assert.Nil(t, second.Total, "La paginación por cursor no debería calcular el total")
+	assert.Equal(t, []string{"uno@test.com"}, emails(second.Items), "Debería continuar después del cursor")
+	assert.Empty(t, second.NextCursor, "La última página no debería tener cursor")
+}
+
+func listPage(t *testing.T, repo port.UserRepository, query model.UserQuery) *sharedmodel.Page[model.User] {
+	t.Helper()
+	page, err := repo.List(context.Background(), normalizedQuery(t, query))
+	require.NoError(t, err, "Error al listar los usuarios")
+	return page
+}
+
+func TestUserRepositoryImpl_List_InvalidCursor(t *testing.T) {
+	// Arrange
+	repo := persistence.NewUserRepositoryImpl(setupSQLiteDB(t))
+	cursor := sharedmodel.Cursor{Sort: "name", Value: "Ana", ID: 5}
+
+	// Act
+	_, err := repo.List(context.Background(), normalizedQuery(t, model.UserQuery{
+		Sort: sharedmodel.Sort{Field: "email"},
+		Page: sharedmodel.PageRequest{Cursor: cursor.Encode()},
+	}))
+
+	// Assert
+	assert.ErrorIs(t, err, sharedmodel.ErrInvalidCursor, "Un cursor de otro orden debería rechazarse")
+}
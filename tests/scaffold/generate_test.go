@@ -0,0 +1,134 @@
+package scaffold_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-hexagonal-template/internal/scaffold"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderProduct(t *testing.T) []scaffold.File {
+	t.Helper()
+	spec, err := scaffold.NewSpec("product", "title:string,price:int,published_at:time")
+	require.NoError(t, err)
+	files, err := scaffold.Render(spec, "example.com/shop", time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return files
+}
+
+func TestRender_GeneratesEveryLayer(t *testing.T) {
+	// Act
+	files := renderProduct(t)
+
+	// Assert
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"internal/modules/product/module.go",
+		"internal/modules/product/domain/model/product.go",
+		"internal/modules/product/domain/model/errors.go",
+		"internal/modules/product/domain/port/product_repository.go",
+		"internal/modules/product/application/product_create.go",
+		"internal/modules/product/application/product_get.go",
+		"internal/modules/product/application/product_list.go",
+		"internal/modules/product/application/product_update.go",
+		"internal/modules/product/application/product_delete.go",
+		"internal/modules/product/infrastructure/persistence/product_repository_impl.go",
+		"internal/modules/product/infrastructure/persistence/migrations.go",
+		"internal/handlers/product.go",
+		"tests/mocks/product_repository_mock.go",
+		"tests/modules/product/application/product_test.go",
+		"internal/modules/product/infrastructure/persistence/migrations/20261017093000_create_products.up.sql",
+		"internal/modules/product/infrastructure/persistence/migrations/20261017093000_create_products.mysql.up.sql",
+		"internal/modules/product/infrastructure/persistence/migrations/20261017093000_create_products.sqlite.up.sql",
+		"internal/modules/product/infrastructure/persistence/migrations/20261017093000_create_products.down.sql",
+	}, paths)
+}
+
+func TestRender_ProducesValidGoWithModuleImports(t *testing.T) {
+	files := renderProduct(t)
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Path, ".go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(token.NewFileSet(), file.Path, file.Content, parser.ImportsOnly)
+		require.NoError(t, err, "%s debería ser código Go válido", file.Path)
+		for _, spec := range parsed.Imports {
+			if strings.Contains(spec.Path.Value, "/internal/") {
+				assert.True(t, strings.HasPrefix(spec.Path.Value, `"example.com/shop/`), "%s importa %s fuera del módulo", file.Path, spec.Path.Value)
+			}
+		}
+	}
+}
+
+func TestRender_MigrationCreatesTable(t *testing.T) {
+	files := renderProduct(t)
+
+	up := string(files[len(files)-4].Content)
+	assert.Contains(t, up, "CREATE TABLE IF NOT EXISTS products")
+	assert.Contains(t, up, "title TEXT NOT NULL")
+	assert.Contains(t, up, "published_at TIMESTAMPTZ,")
+	assert.Equal(t, "DROP TABLE IF EXISTS products;\n", string(files[len(files)-1].Content))
+}
+
+func TestRender_MigrationHasDriverVariants(t *testing.T) {
+	files := renderProduct(t)
+
+	mysqlUp := string(files[len(files)-3].Content)
+	assert.Contains(t, mysqlUp, "id         BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY")
+	assert.Contains(t, mysqlUp, "published_at DATETIME(6),")
+	sqliteUp := string(files[len(files)-2].Content)
+	assert.Contains(t, sqliteUp, "id         INTEGER PRIMARY KEY AUTOINCREMENT")
+	assert.Contains(t, sqliteUp, "published_at DATETIME,")
+}
+
+func TestWrite_RefusesToOverwrite(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	files := renderProduct(t)
+	existing := filepath.Join(root, "internal/handlers/product.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0o755))
+	require.NoError(t, os.WriteFile(existing, []byte("package handlers\n"), 0o644))
+
+	// Act
+	err := scaffold.Write(root, files)
+
+	// Assert
+	assert.ErrorIs(t, err, scaffold.ErrFileExists)
+	_, statErr := os.Stat(filepath.Join(root, files[0].Path))
+	assert.ErrorIs(t, statErr, os.ErrNotExist, "No debería escribirse ningún fichero si alguno ya existe")
+	content, _ := os.ReadFile(existing)
+	assert.Equal(t, "package handlers\n", string(content))
+}
+
+func TestWrite_CreatesFiles(t *testing.T) {
+	root := t.TempDir()
+	files := renderProduct(t)
+
+	require.NoError(t, scaffold.Write(root, files))
+
+	content, err := os.ReadFile(filepath.Join(root, files[0].Path))
+	require.NoError(t, err)
+	assert.Equal(t, files[0].Content, content)
+}
+
+func TestModulePath_ReadsGoMod(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("// comentario\nmodule example.com/shop\n\ngo 1.22\n"), 0o644))
+
+	module, err := scaffold.ModulePath(root)
+
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/shop", module)
+}
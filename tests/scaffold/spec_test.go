@@ -0,0 +1,94 @@
+package scaffold_test
+
+import (
+	"testing"
+
+	"go-hexagonal-template/internal/scaffold"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpec_DerivesNamesInEveryConvention(t *testing.T) {
+	// Act
+	spec, err := scaffold.NewSpec("OrderItem", "title:string")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "order_item", spec.Snake)
+	assert.Equal(t, "orderitem", spec.Package)
+	assert.Equal(t, "OrderItem", spec.Pascal)
+	assert.Equal(t, "orderItem", spec.Camel)
+	assert.Equal(t, "OrderItems", spec.Plural)
+	assert.Equal(t, "order_items", spec.Table)
+	assert.Equal(t, "order-items", spec.Route)
+	assert.Equal(t, "order item", spec.Label)
+	assert.Equal(t, "ORDER_ITEM_NOT_FOUND", spec.ErrorCode)
+}
+
+func TestNewSpec_Pluralizes(t *testing.T) {
+	tests := map[string]string{
+		"category": "categories",
+		"box":      "boxes",
+		"day":      "days",
+		"address":  "addresses",
+		"product":  "products",
+	}
+
+	for name, table := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec, err := scaffold.NewSpec(name, "title:string")
+
+			require.NoError(t, err)
+			assert.Equal(t, table, spec.Table)
+		})
+	}
+}
+
+func TestNewSpec_RejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "1product", "pro duct", "func", "model", "time"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := scaffold.NewSpec(name, "title:string")
+
+			assert.ErrorIs(t, err, scaffold.ErrInvalidModuleName, "El nombre %q no debería aceptarse", name)
+		})
+	}
+}
+
+func TestParseFields_MapsTypes(t *testing.T) {
+	// Act
+	fields, err := scaffold.ParseFields("title:string, price:int,api_url:string,published_at:time,active:bool")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, fields, 5)
+	assert.Equal(t, scaffold.Field{
+		Name: "title", GoName: "Title", GoType: "string", SQLType: "TEXT", MySQLType: "TEXT", SQLiteType: "TEXT", Required: true,
+		Sample: `"title de prueba"`, Updated: `"title actualizado"`,
+	}, fields[0])
+	assert.Equal(t, "int", fields[1].GoType)
+	assert.False(t, fields[1].Required, "Solo los campos de texto deberían ser obligatorios")
+	assert.Equal(t, "APIURL", fields[2].GoName)
+	assert.Equal(t, "time.Time", fields[3].GoType)
+	assert.Equal(t, "DATETIME(6)", fields[3].MySQLType)
+	assert.Equal(t, "DATETIME", fields[3].SQLiteType)
+	assert.Equal(t, "BOOLEAN", fields[4].SQLType)
+}
+
+func TestParseFields_RejectsInvalidDefinitions(t *testing.T) {
+	tests := map[string]string{
+		"vacío":          "",
+		"sin tipo":       "title",
+		"tipo no válido": "title:decimal",
+		"reservado":      "id:int",
+		"repetido":       "title:string,Title:string",
+	}
+
+	for name, definition := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := scaffold.ParseFields(definition)
+
+			assert.ErrorIs(t, err, scaffold.ErrInvalidField)
+		})
+	}
+}